@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/veeam/powerbi-backup-go/internal/api"
@@ -12,16 +14,38 @@ import (
 	"github.com/veeam/powerbi-backup-go/internal/backup"
 	"github.com/veeam/powerbi-backup-go/internal/config"
 	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
 	"github.com/veeam/powerbi-backup-go/internal/restore"
 	"github.com/veeam/powerbi-backup-go/internal/storage"
 )
 
 func main() {
 	// Define command-line flags
-	cmd := flag.String("cmd", "backup", "Command to execute: backup or restore")
+	cmd := flag.String("cmd", "backup", "Command to execute: backup, restore, preflight, gateways, pipelines, restore-pipelines or lineage")
 	workspaceID := flag.String("workspace-id", "", "Power BI workspace ID")
 	backupPathArg := flag.String("backup-path", "", "Path to backup for restore operation")
 	allWorkspaces := flag.Bool("all", false, "Backup all workspaces")
+	includeName := flag.String("include-name", "", "With --all: only back up workspaces whose name matches this regex")
+	excludeName := flag.String("exclude-name", "", "With --all: skip workspaces whose name matches this regex")
+	workspaceTypes := flag.String("workspace-type", "", "With --all: comma-separated workspace types to back up (e.g. Workspace,PersonalGroup)")
+	capacityIDs := flag.String("capacity-id", "", "With --all: comma-separated capacity IDs to back up")
+	workspaceStates := flag.String("workspace-state", "", "With --all: comma-separated workspace states to back up (e.g. Active)")
+	includeIDs := flag.String("include-ids", "", "With --all: comma-separated workspace IDs to back up")
+	excludeIDs := flag.String("exclude-ids", "", "With --all: comma-separated workspace IDs to skip")
+	dryRun := flag.Bool("dry-run", false, "With --all: list the selected workspaces without backing them up")
+	runID := flag.String("run-id", "", "With --all: run ID of the checkpoint; rerunning with the same ID resumes the run")
+	components := flag.String("components", "", "Comma-separated backup components (default all): "+strings.Join(backup.AllComponents, ","))
+	output := flag.String("output", "", "Write the preflight report or lineage graph to this file")
+	format := flag.String("format", "json", "With lineage: output format, json or dot")
+	metadataOnly := flag.Bool("metadata-only", false, "Back up metadata only, without PBIX and definition files")
+	restoreAccess := flag.Bool("restore-access", false, "Re-grant workspace access recorded in the backup on restore")
+	assignCapacity := flag.Bool("assign-capacity", false, "Assign the target workspace to the capacity recorded in the backup on restore")
+	capacityMap := flag.String("capacity-map", "", "With --assign-capacity: comma-separated source=target capacity ID pairs")
+	createWorkspace := flag.Bool("create-workspace", false, "Restore into a new workspace created with the settings recorded in the backup")
+	workspaceName := flag.String("workspace-name", "", "With --create-workspace: name of the new workspace (default: the backed-up workspace's name)")
+	workspaceMap := flag.String("workspace-map", "", "With restore-pipelines: comma-separated source=target workspace ID pairs for stage assignment")
+	pipelineIDs := flag.String("pipeline-ids", "", "With restore-pipelines: comma-separated source pipeline IDs to restore (default all)")
+	restoreItemPermissions := flag.Bool("restore-item-permissions", false, "Re-grant direct dataset and report access recorded in the backup on restore")
 	flag.Parse()
 
 	// Load configuration
@@ -48,28 +72,99 @@ func main() {
 	apiClient := api.NewClient(authService, settings)
 	storageService := storage.NewStorageService(settings.BackupPath)
 
+	// Detect backups left behind by interrupted runs
+	if _, err := storageService.CheckIncompleteBackups(settings.CleanupIncompleteBackups); err != nil {
+		logger.LogWarn(fmt.Sprintf("Failed to check for incomplete backups: %v", err))
+	}
+
 	ctx := context.Background()
 
+	// Workspace selection for --all
+	filter := backup.WorkspaceFilter{
+		NameRegex:        *includeName,
+		ExcludeNameRegex: *excludeName,
+		Types:            splitList(*workspaceTypes),
+		CapacityIDs:      splitList(*capacityIDs),
+		States:           splitList(*workspaceStates),
+		IncludeIDs:       splitList(*includeIDs),
+		ExcludeIDs:       splitList(*excludeIDs),
+	}
+
 	// Execute command
 	switch *cmd {
 	case "backup":
+		selected, err := backup.ResolveComponents(splitList(*components), *metadataOnly)
+		if err != nil {
+			logger.LogError("Invalid backup components", err)
+			os.Exit(1)
+		}
+		backupOpts := backup.Options{Components: selected}
+
 		if *allWorkspaces {
-			backupAllWorkspaces(ctx, apiClient, storageService)
+			backupAllWorkspaces(ctx, *runID, filter, *dryRun, backupOpts, apiClient, storageService)
 		} else if *workspaceID != "" {
-			backupWorkspace(ctx, *workspaceID, apiClient, storageService)
+			backupWorkspace(ctx, *workspaceID, backupOpts, apiClient, storageService)
 		} else {
 			logger.LogError("Please provide --workspace-id or use --all flag", nil)
 			flag.Usage()
 			os.Exit(1)
 		}
 
+	case "preflight":
+		if !*allWorkspaces && *workspaceID == "" {
+			logger.LogError("Please provide --workspace-id or use --all flag", nil)
+			flag.Usage()
+			os.Exit(1)
+		}
+		preflight(ctx, *workspaceID, *allWorkspaces, filter, *output, apiClient, storageService)
+
+	case "gateways":
+		// Dataset bindings are collected for the selected workspaces, if any
+		backupGateways(ctx, *workspaceID, *allWorkspaces, filter, apiClient, storageService)
+
+	case "pipelines":
+		backupPipelines(ctx, apiClient, storageService)
+
+	case "lineage":
+		// Without --workspace-id the graph covers every backed-up workspace
+		lineage(splitList(*workspaceID), *format, *output, apiClient, storageService)
+
+	case "restore-pipelines":
+		if *backupPathArg == "" {
+			logger.LogError("restore-pipelines requires --backup-path of a pipeline inventory", nil)
+			flag.Usage()
+			os.Exit(1)
+		}
+		opts := restore.PipelineOptions{
+			PipelineIDs:      splitList(*pipelineIDs),
+			WorkspaceMapping: parseMapping(*workspaceMap),
+		}
+		restorePipelines(ctx, *backupPathArg, opts, apiClient, storageService)
+
 	case "restore":
-		if *workspaceID == "" || *backupPathArg == "" {
-			logger.LogError("Restore requires --workspace-id and --backup-path", nil)
+		if (*workspaceID == "" && !*createWorkspace) || *backupPathArg == "" {
+			logger.LogError("Restore requires --backup-path and --workspace-id or --create-workspace", nil)
 			flag.Usage()
 			os.Exit(1)
 		}
-		restoreWorkspace(ctx, *workspaceID, *backupPathArg, apiClient, storageService)
+		opts := restore.Options{
+			RestoreAccess:          *restoreAccess,
+			RestoreItemPermissions: *restoreItemPermissions,
+			AssignCapacity:         *assignCapacity,
+			CapacityMapping:        parseMapping(*capacityMap),
+		}
+		targetWorkspaceID := *workspaceID
+		if *createWorkspace {
+			restoreService := restore.NewService(apiClient, storageService)
+			created, err := restoreService.CreateWorkspaceFromBackup(ctx, *backupPathArg, *workspaceName)
+			if err != nil {
+				logger.LogError("Failed to create workspace", err)
+				os.Exit(1)
+			}
+			targetWorkspaceID = created
+			opts.ApplyWorkspaceSettings = true
+		}
+		restoreWorkspace(ctx, targetWorkspaceID, *backupPathArg, opts, apiClient, storageService)
 
 	default:
 		logger.LogError(fmt.Sprintf("Unknown command: %s", *cmd), nil)
@@ -78,13 +173,13 @@ func main() {
 	}
 }
 
-func backupWorkspace(ctx context.Context, workspaceID string, apiClient *api.Client, storageService *storage.StorageService) {
+func backupWorkspace(ctx context.Context, workspaceID string, opts backup.Options, apiClient *api.Client, storageService *storage.StorageService) {
 	logger.LogInfo(fmt.Sprintf("Starting backup for workspace: %s", workspaceID))
 
 	backupService := backup.NewService(apiClient, storageService)
 
 	startTime := time.Now()
-	backupData, err := backupService.BackupWorkspace(ctx, workspaceID)
+	backupData, err := backupService.BackupWorkspace(ctx, workspaceID, opts)
 	if err != nil {
 		logger.LogError("Backup failed", err)
 		os.Exit(1)
@@ -99,61 +194,240 @@ func backupWorkspace(ctx context.Context, workspaceID string, apiClient *api.Cli
 	logger.LogInfo(fmt.Sprintf("   - Dashboards: %d", len(backupData.Dashboards)))
 	logger.LogInfo(fmt.Sprintf("   - Apps: %d", len(backupData.Apps)))
 	logger.LogInfo(fmt.Sprintf("   - Refresh Schedules: %d", len(backupData.RefreshSchedules)))
+	logger.LogInfo(fmt.Sprintf("   - Status: %s", backupData.Status))
+
+	for _, item := range backupData.Items {
+		if item.Outcome != models.ItemOutcomeFailed {
+			continue
+		}
+		logger.LogWarn(fmt.Sprintf("   ❌ %s %s failed after %d attempt(s): %s", item.ItemType, item.Name, item.Attempts, item.Error))
+	}
 }
 
-func backupAllWorkspaces(ctx context.Context, apiClient *api.Client, storageService *storage.StorageService) {
+func backupAllWorkspaces(ctx context.Context, runID string, filter backup.WorkspaceFilter, dryRun bool, opts backup.Options, apiClient *api.Client, storageService *storage.StorageService) {
+	backupService := backup.NewService(apiClient, storageService)
+
+	// Resume the run if a checkpoint with this ID exists
+	if runID != "" && !dryRun {
+		run, err := storageService.LoadRun(runID)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to load run %s", runID), err)
+			os.Exit(1)
+		}
+		if run != nil {
+			logger.LogInfo(fmt.Sprintf("Resuming backup run %s started %s", run.RunID, run.StartedAt.Format(time.RFC3339)))
+			if err := backupService.RunBackup(ctx, run); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	logger.LogInfo("Fetching all workspaces...")
 
-	workspacesResp, err := apiClient.GetWorkspaces(ctx)
+	workspaces, err := backupService.SelectWorkspaces(ctx, filter)
 	if err != nil {
-		logger.LogError("Failed to fetch workspaces", err)
+		logger.LogError("Failed to select workspaces", err)
 		os.Exit(1)
 	}
 
-	value, ok := workspacesResp["value"].([]interface{})
-	if !ok || len(value) == 0 {
-		logger.LogWarn("No workspaces found")
+	if len(workspaces) == 0 {
+		logger.LogWarn("No workspaces match the filter")
 		return
 	}
 
-	logger.LogInfo(fmt.Sprintf("Found %d workspaces", len(value)))
+	if dryRun {
+		logger.LogInfo(fmt.Sprintf("🔎 Dry run - %d workspaces would be backed up:", len(workspaces)))
+		for _, ws := range workspaces {
+			logger.LogInfo(fmt.Sprintf("   - %s (%s) type=%s state=%s capacity=%s", ws.Name, ws.ID, ws.Type, ws.State, ws.CapacityID))
+		}
+		return
+	}
 
+	run := backup.NewRun(runID, workspaces, opts)
+	logger.LogInfo(fmt.Sprintf("Starting backup run %s (rerun with --run-id %s to resume)", run.RunID, run.RunID))
+	if err := backupService.RunBackup(ctx, run); err != nil {
+		os.Exit(1)
+	}
+}
+
+func preflight(ctx context.Context, workspaceID string, all bool, filter backup.WorkspaceFilter, output string, apiClient *api.Client, storageService *storage.StorageService) {
 	backupService := backup.NewService(apiClient, storageService)
-	successCount := 0
-	failCount := 0
 
-	for i, item := range value {
-		workspaceMap, ok := item.(map[string]interface{})
-		if !ok {
+	workspaces := []models.WorkspaceRef{{ID: workspaceID}}
+	if all {
+		selected, err := backupService.SelectWorkspaces(ctx, filter)
+		if err != nil {
+			logger.LogError("Failed to select workspaces", err)
+			os.Exit(1)
+		}
+		workspaces = selected
+	}
+
+	report := backupService.Preflight(ctx, workspaces)
+
+	logger.LogInfo("📋 Preflight report:")
+	for _, ws := range report.Workspaces {
+		if !ws.Accessible || ws.Error != "" {
+			logger.LogWarn(fmt.Sprintf("   ❌ %s (%s): %s", ws.WorkspaceName, ws.WorkspaceID, ws.Error))
 			continue
 		}
+		logger.LogInfo(fmt.Sprintf("   ✅ %s (%s) capacity=%s: %d reports, %d datasets, %d dataflows, %d dashboards",
+			ws.WorkspaceName, ws.WorkspaceID, ws.CapacityType, ws.Reports, ws.Datasets, ws.Dataflows, ws.Dashboards))
+		for _, item := range ws.Items {
+			if !item.Exportable {
+				logger.LogWarn(fmt.Sprintf("      ❌ %s will not be exported: %s", item.Name, item.Reason))
+			} else if item.Reason != "" {
+				logger.LogInfo(fmt.Sprintf("      ⚠️  %s: %s", item.Name, item.Reason))
+			}
+		}
+	}
 
-		wsID, _ := workspaceMap["id"].(string)
-		wsName, _ := workspaceMap["name"].(string)
+	if output != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.WriteFile(output, data, 0644)
+		}
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to write preflight report: %s", output), err)
+			os.Exit(1)
+		}
+		logger.LogInfo(fmt.Sprintf("Preflight report written to: %s", output))
+	}
+}
 
-		logger.LogInfo(fmt.Sprintf("[%d/%d] Backing up workspace: %s (%s)", i+1, len(value), wsName, wsID))
+func backupGateways(ctx context.Context, workspaceID string, all bool, filter backup.WorkspaceFilter, apiClient *api.Client, storageService *storage.StorageService) {
+	backupService := backup.NewService(apiClient, storageService)
 
-		_, err := backupService.BackupWorkspace(ctx, wsID)
+	var workspaces []models.WorkspaceRef
+	if all {
+		selected, err := backupService.SelectWorkspaces(ctx, filter)
 		if err != nil {
-			logger.LogError(fmt.Sprintf("Failed to backup workspace: %s", wsName), err)
-			failCount++
-			continue
+			logger.LogError("Failed to select workspaces", err)
+			os.Exit(1)
 		}
+		workspaces = selected
+	} else if workspaceID != "" {
+		workspaces = []models.WorkspaceRef{{ID: workspaceID}}
+	}
+
+	inventory, path, err := backupService.BackupGateways(ctx, workspaces)
+	if err != nil {
+		logger.LogError("Gateway inventory backup failed", err)
+		os.Exit(1)
+	}
+
+	datasources := 0
+	for _, gateway := range inventory.Gateways {
+		datasources += len(gateway.Datasources)
+	}
+	logger.LogInfo(fmt.Sprintf("✅ Gateway inventory saved to: %s (status: %s)", path, inventory.Status))
+	logger.LogInfo("📊 Summary:")
+	logger.LogInfo(fmt.Sprintf("   - Gateways: %d", len(inventory.Gateways)))
+	logger.LogInfo(fmt.Sprintf("   - Datasources: %d", datasources))
+	logger.LogInfo(fmt.Sprintf("   - Dataset bindings: %d", len(inventory.Bindings)))
+}
+
+func lineage(workspaceIDs []string, format, output string, apiClient *api.Client, storageService *storage.StorageService) {
+	backupService := backup.NewService(apiClient, storageService)
+
+	graph, err := backupService.BuildLineage(workspaceIDs)
+	if err != nil {
+		logger.LogError("Failed to build lineage graph", err)
+		os.Exit(1)
+	}
+
+	var data []byte
+	switch format {
+	case "json":
+		data, err = json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			logger.LogError("Failed to marshal lineage graph", err)
+			os.Exit(1)
+		}
+	case "dot":
+		data = []byte(backup.LineageDOT(graph))
+	default:
+		logger.LogError(fmt.Sprintf("Unknown lineage format: %s (expected json or dot)", format), nil)
+		os.Exit(1)
+	}
+
+	external := 0
+	for _, ws := range graph.Workspaces {
+		external += len(ws.ExternalDependencies)
+	}
+	logger.LogInfo(fmt.Sprintf("📊 Lineage: %d workspaces, %d items, %d dependencies, %d across workspaces",
+		len(graph.Workspaces), len(graph.Nodes), len(graph.Edges), external))
+
+	if output == "" {
+		fmt.Println(string(data))
+		return
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to write lineage graph: %s", output), err)
+		os.Exit(1)
+	}
+	logger.LogInfo(fmt.Sprintf("Lineage graph written to: %s", output))
+}
+
+func backupPipelines(ctx context.Context, apiClient *api.Client, storageService *storage.StorageService) {
+	backupService := backup.NewService(apiClient, storageService)
 
-		successCount++
+	inventory, path, err := backupService.BackupPipelines(ctx)
+	if err != nil {
+		logger.LogError("Deployment pipeline backup failed", err)
+		os.Exit(1)
 	}
 
-	logger.LogInfo(fmt.Sprintf("✅ All workspaces backup completed: %d succeeded, %d failed", successCount, failCount))
+	logger.LogInfo(fmt.Sprintf("✅ Pipeline inventory saved to: %s (status: %s)", path, inventory.Status))
+	logger.LogInfo(fmt.Sprintf("   - Pipelines: %d", len(inventory.Pipelines)))
 }
 
-func restoreWorkspace(ctx context.Context, workspaceID, backupPath string, apiClient *api.Client, storageService *storage.StorageService) {
+func restorePipelines(ctx context.Context, inventoryFile string, opts restore.PipelineOptions, apiClient *api.Client, storageService *storage.StorageService) {
+	restoreService := restore.NewService(apiClient, storageService)
+
+	items, err := restoreService.RestorePipelines(ctx, inventoryFile, opts)
+	if err != nil {
+		logger.LogError("Deployment pipeline restore failed", err)
+		os.Exit(1)
+	}
+
+	logRestoreSummary(&models.RestoreResult{Items: items})
+}
+
+// parseMapping parses a comma-separated list of source=target pairs
+func parseMapping(value string) map[string]string {
+	mapping := make(map[string]string)
+	for _, pair := range splitList(value) {
+		source, target, ok := strings.Cut(pair, "=")
+		if !ok {
+			logger.LogError(fmt.Sprintf("Invalid mapping: %s (expected source=target)", pair), nil)
+			os.Exit(1)
+		}
+		mapping[strings.TrimSpace(source)] = strings.TrimSpace(target)
+	}
+	return mapping
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var list []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+func restoreWorkspace(ctx context.Context, workspaceID, backupPath string, opts restore.Options, apiClient *api.Client, storageService *storage.StorageService) {
 	logger.LogInfo(fmt.Sprintf("Starting restore for workspace: %s", workspaceID))
 	logger.LogInfo(fmt.Sprintf("From backup: %s", backupPath))
 
 	restoreService := restore.NewService(apiClient, storageService)
 
 	startTime := time.Now()
-	err := restoreService.RestoreWorkspace(ctx, workspaceID, backupPath)
+	result, err := restoreService.RestoreWorkspace(ctx, workspaceID, backupPath, opts)
 	if err != nil {
 		logger.LogError("Restore failed", err)
 		os.Exit(1)
@@ -161,4 +435,24 @@ func restoreWorkspace(ctx context.Context, workspaceID, backupPath string, apiCl
 
 	duration := time.Since(startTime)
 	logger.LogInfo(fmt.Sprintf("✅ Restore completed in %v", duration))
+	logRestoreSummary(result)
+}
+
+func logRestoreSummary(result *models.RestoreResult) {
+	counts := make(map[string]map[models.ItemOutcome]int)
+	var itemTypes []string
+	for _, item := range result.Items {
+		if counts[item.ItemType] == nil {
+			counts[item.ItemType] = make(map[models.ItemOutcome]int)
+			itemTypes = append(itemTypes, item.ItemType)
+		}
+		counts[item.ItemType][item.Outcome]++
+	}
+
+	logger.LogInfo("📊 Summary:")
+	for _, itemType := range itemTypes {
+		c := counts[itemType]
+		logger.LogInfo(fmt.Sprintf("   - %s: %d restored, %d skipped, %d failed", itemType,
+			c[models.ItemOutcomeRestored], c[models.ItemOutcomeSkipped], c[models.ItemOutcomeFailed]))
+	}
 }
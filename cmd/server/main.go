@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/veeam/powerbi-backup-go/internal/api"
@@ -15,6 +16,7 @@ import (
 	"github.com/veeam/powerbi-backup-go/internal/backup"
 	"github.com/veeam/powerbi-backup-go/internal/config"
 	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
 	"github.com/veeam/powerbi-backup-go/internal/restore"
 	"github.com/veeam/powerbi-backup-go/internal/storage"
 )
@@ -36,13 +38,43 @@ type APIResponse struct {
 }
 
 type BackupRequest struct {
-	WorkspaceID string `json:"workspace_id"`
-	All         bool   `json:"all"`
+	WorkspaceID  string                 `json:"workspace_id"`
+	All          bool                   `json:"all"`
+	Filter       backup.WorkspaceFilter `json:"filter"`
+	DryRun       bool                   `json:"dry_run"`
+	Components   []string               `json:"components,omitempty"`
+	MetadataOnly bool                   `json:"metadata_only"`
+	RunID        string                 `json:"run_id,omitempty"` // Resumes the run when its checkpoint exists
+}
+
+type PreflightRequest struct {
+	WorkspaceID string                 `json:"workspace_id"`
+	All         bool                   `json:"all"`
+	Filter      backup.WorkspaceFilter `json:"filter"`
+}
+
+type GatewayBackupRequest struct {
+	WorkspaceID string                 `json:"workspace_id"`
+	All         bool                   `json:"all"`
+	Filter      backup.WorkspaceFilter `json:"filter"`
+}
+
+type PipelineRestoreRequest struct {
+	BackupPath       string            `json:"backup_path"`
+	PipelineIDs      []string          `json:"pipeline_ids"`
+	WorkspaceMapping map[string]string `json:"workspace_mapping"`
 }
 
 type RestoreRequest struct {
-	WorkspaceID string `json:"workspace_id"`
-	BackupPath  string `json:"backup_path"`
+	WorkspaceID            string            `json:"workspace_id"`
+	BackupPath             string            `json:"backup_path"`
+	RestoreAccess          bool              `json:"restore_access"`
+	RestoreItemPermissions bool              `json:"restore_item_permissions"`
+	AssignCapacity         bool              `json:"assign_capacity"`
+	CapacityMapping        map[string]string `json:"capacity_mapping"`
+	CreateWorkspace        bool              `json:"create_workspace"`
+	WorkspaceName          string            `json:"workspace_name"`
+	Wait                   bool              `json:"wait"` // Run synchronously and return the restore report
 }
 
 type CreateWorkspaceRequest struct {
@@ -62,15 +94,32 @@ type WorkspaceInfo struct {
 }
 
 type BackupInfo struct {
-	WorkspaceID   string    `json:"workspace_id"`
-	WorkspaceName string    `json:"workspace_name"`
-	Timestamp     time.Time `json:"timestamp"`
-	Path          string    `json:"path"`
-	Reports       int       `json:"reports"`
-	Datasets      int       `json:"datasets"`
-	Dashboards    int       `json:"dashboards"`
-	Dataflows     int       `json:"dataflows"`
-	Apps          int       `json:"apps"`
+	WorkspaceID   string              `json:"workspace_id"`
+	WorkspaceName string              `json:"workspace_name"`
+	Timestamp     time.Time           `json:"timestamp"`
+	Path          string              `json:"path"`
+	Status        string              `json:"status"`
+	Components    []string            `json:"components,omitempty"`
+	Reports       int                 `json:"reports"`
+	Datasets      int                 `json:"datasets"`
+	Dashboards    int                 `json:"dashboards"`
+	Dataflows     int                 `json:"dataflows"`
+	Apps          int                 `json:"apps"`
+	Exported      int                 `json:"exported"`
+	Skipped       int                 `json:"skipped"`
+	Failed        int                 `json:"failed"`
+	FailedItems   []models.ItemResult `json:"failed_items,omitempty"`
+	Restores      []RestoreInfo       `json:"restores,omitempty"`
+}
+
+// RestoreInfo summarizes a restore report saved next to a backup
+type RestoreInfo struct {
+	Timestamp         time.Time           `json:"timestamp"`
+	TargetWorkspaceID string              `json:"target_workspace_id"`
+	Restored          int                 `json:"restored"`
+	Skipped           int                 `json:"skipped"`
+	Failed            int                 `json:"failed"`
+	AttentionItems    []models.ItemResult `json:"attention_items,omitempty"` // Failed items and skipped items needing manual follow-up
 }
 
 func main() {
@@ -98,6 +147,11 @@ func main() {
 	apiClient := api.NewClient(authService, settings)
 	storageService := storage.NewStorageService(settings.BackupPath)
 
+	// Detect backups left behind by interrupted runs
+	if _, err := storageService.CheckIncompleteBackups(settings.CleanupIncompleteBackups); err != nil {
+		logger.LogWarn(fmt.Sprintf("Failed to check for incomplete backups: %v", err))
+	}
+
 	server := &Server{
 		apiClient:      apiClient,
 		storageService: storageService,
@@ -114,7 +168,13 @@ func main() {
 	mux.HandleFunc("/api/workspace/create", server.handleCreateWorkspace)
 	mux.HandleFunc("/api/backup", server.handleBackup)
 	mux.HandleFunc("/api/restore", server.handleRestore)
+	mux.HandleFunc("/api/restores", server.handleListRestores)
 	mux.HandleFunc("/api/backups", server.handleListBackups)
+	mux.HandleFunc("/api/preflight", server.handlePreflight)
+	mux.HandleFunc("/api/gateways/backup", server.handleGatewayBackup)
+	mux.HandleFunc("/api/pipelines/backup", server.handlePipelineBackup)
+	mux.HandleFunc("/api/lineage", server.handleLineage)
+	mux.HandleFunc("/api/pipelines/restore", server.handlePipelineRestore)
 
 	// Static files
 	webDir := filepath.Join(".", "web", "static")
@@ -295,28 +355,75 @@ func (s *Server) handleBackup(w http.ResponseWriter, r *http.Request) {
 
 	ctx := context.Background()
 
+	components, err := backup.ResolveComponents(req.Components, req.MetadataOnly)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	opts := backup.Options{Components: components}
+
 	if req.All {
-		// Backup all workspaces (async)
-		go s.backupAllWorkspaces(ctx)
-		
+		backupService := backup.NewService(s.apiClient, s.storageService)
+
+		// Resume the run if a checkpoint with this ID exists
+		var run *models.BackupRun
+		if req.RunID != "" && !req.DryRun {
+			run, err = s.storageService.LoadRun(req.RunID)
+			if err != nil {
+				s.sendError(w, http.StatusBadRequest, fmt.Sprintf("Failed to load run: %v", err))
+				return
+			}
+		}
+
+		if run == nil {
+			// Select workspaces up front so filter errors and dry runs are answered directly
+			workspaces, err := backupService.SelectWorkspaces(ctx, req.Filter)
+			if err != nil {
+				s.sendError(w, http.StatusBadRequest, fmt.Sprintf("Failed to select workspaces: %v", err))
+				return
+			}
+
+			if req.DryRun {
+				response := APIResponse{
+					Success: true,
+					Message: fmt.Sprintf("%d workspaces would be backed up", len(workspaces)),
+					Data: map[string]interface{}{
+						"status":     "dry_run",
+						"workspaces": workspaces,
+					},
+				}
+				s.sendJSON(w, http.StatusOK, response)
+				return
+			}
+
+			run = backup.NewRun(req.RunID, workspaces, opts)
+		}
+
+		// Backup selected workspaces (async)
+		go s.backupAllWorkspaces(ctx, run)
+
 		response := APIResponse{
 			Success: true,
-			Message: "Backup of all workspaces started",
+			Message: fmt.Sprintf("Backup run %s of %d workspaces started", run.RunID, len(run.Workspaces)),
 			Data: map[string]interface{}{
-				"status":    "started",
-				"timestamp": time.Now().Format(time.RFC3339),
+				"status":     "started",
+				"run_id":     run.RunID,
+				"workspaces": len(run.Workspaces),
+				"components": run.Components,
+				"timestamp":  time.Now().Format(time.RFC3339),
 			},
 		}
 		s.sendJSON(w, http.StatusAccepted, response)
 	} else if req.WorkspaceID != "" {
 		// Backup single workspace (async)
-		go s.backupWorkspace(ctx, req.WorkspaceID)
+		go s.backupWorkspace(ctx, req.WorkspaceID, opts)
 
 		response := APIResponse{
 			Success: true,
 			Message: fmt.Sprintf("Backup started for workspace: %s", req.WorkspaceID),
 			Data: map[string]interface{}{
 				"workspace_id": req.WorkspaceID,
+				"components":   components,
 				"status":       "started",
 				"timestamp":    time.Now().Format(time.RFC3339),
 			},
@@ -327,6 +434,204 @@ func (s *Server) handleBackup(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Preflight handler - checks access and exportability without backing anything up
+func (s *Server) handlePreflight(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req PreflightRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.sendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx := r.Context()
+	backupService := backup.NewService(s.apiClient, s.storageService)
+
+	var workspaces []models.WorkspaceRef
+	switch {
+	case req.All:
+		selected, err := backupService.SelectWorkspaces(ctx, req.Filter)
+		if err != nil {
+			s.sendError(w, http.StatusBadRequest, fmt.Sprintf("Failed to select workspaces: %v", err))
+			return
+		}
+		workspaces = selected
+	case req.WorkspaceID != "":
+		workspaces = []models.WorkspaceRef{{ID: req.WorkspaceID}}
+	default:
+		s.sendError(w, http.StatusBadRequest, "workspace_id or all flag required")
+		return
+	}
+
+	report := backupService.Preflight(ctx, workspaces)
+
+	response := APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Preflight checked %d workspaces", len(report.Workspaces)),
+		Data:    report,
+	}
+	s.sendJSON(w, http.StatusOK, response)
+}
+
+// Gateway backup handler - saves the tenant gateway inventory and the dataset
+// bindings of the selected workspaces
+func (s *Server) handleGatewayBackup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req GatewayBackupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.sendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ctx := r.Context()
+	backupService := backup.NewService(s.apiClient, s.storageService)
+
+	var workspaces []models.WorkspaceRef
+	if req.All {
+		selected, err := backupService.SelectWorkspaces(ctx, req.Filter)
+		if err != nil {
+			s.sendError(w, http.StatusBadRequest, fmt.Sprintf("Failed to select workspaces: %v", err))
+			return
+		}
+		workspaces = selected
+	} else if req.WorkspaceID != "" {
+		workspaces = []models.WorkspaceRef{{ID: req.WorkspaceID}}
+	}
+
+	inventory, path, err := backupService.BackupGateways(ctx, workspaces)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, fmt.Sprintf("Gateway backup failed: %v", err))
+		return
+	}
+
+	response := APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Backed up %d gateways and %d dataset bindings", len(inventory.Gateways), len(inventory.Bindings)),
+		Data: map[string]interface{}{
+			"path":      path,
+			"inventory": inventory,
+		},
+	}
+	s.sendJSON(w, http.StatusOK, response)
+}
+
+// Lineage handler - dependency graph across the latest workspace backups.
+// workspace_id takes a comma-separated list; format=dot returns Graphviz DOT.
+func (s *Server) handleLineage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var workspaceIDs []string
+	for _, id := range strings.Split(r.URL.Query().Get("workspace_id"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			workspaceIDs = append(workspaceIDs, id)
+		}
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "dot" {
+		s.sendError(w, http.StatusBadRequest, "format must be json or dot")
+		return
+	}
+
+	backupService := backup.NewService(s.apiClient, s.storageService)
+	graph, err := backupService.BuildLineage(workspaceIDs)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to build lineage graph: %v", err))
+		return
+	}
+
+	if format == "dot" {
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(backup.LineageDOT(graph)))
+		return
+	}
+
+	response := APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Lineage of %d workspaces", len(graph.Workspaces)),
+		Data:    graph,
+	}
+	s.sendJSON(w, http.StatusOK, response)
+}
+
+// Pipeline backup handler - saves the tenant's deployment pipelines
+func (s *Server) handlePipelineBackup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	backupService := backup.NewService(s.apiClient, s.storageService)
+	inventory, path, err := backupService.BackupPipelines(r.Context())
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, fmt.Sprintf("Pipeline backup failed: %v", err))
+		return
+	}
+
+	response := APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Backed up %d deployment pipelines", len(inventory.Pipelines)),
+		Data: map[string]interface{}{
+			"path":      path,
+			"inventory": inventory,
+		},
+	}
+	s.sendJSON(w, http.StatusOK, response)
+}
+
+// Pipeline restore handler - recreates pipelines from an inventory and reassigns their stages
+func (s *Server) handlePipelineRestore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req PipelineRestoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.sendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.BackupPath == "" {
+		s.sendError(w, http.StatusBadRequest, "backup_path required")
+		return
+	}
+
+	inventoryFile, err := s.storageService.PipelineInventoryPath(req.BackupPath)
+	if err != nil {
+		s.sendError(w, http.StatusBadRequest, fmt.Sprintf("Invalid backup_path: %v", err))
+		return
+	}
+
+	restoreService := restore.NewService(s.apiClient, s.storageService)
+	items, err := restoreService.RestorePipelines(r.Context(), inventoryFile, restore.PipelineOptions{
+		PipelineIDs:      req.PipelineIDs,
+		WorkspaceMapping: req.WorkspaceMapping,
+	})
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, fmt.Sprintf("Pipeline restore failed: %v", err))
+		return
+	}
+
+	response := APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Pipeline restore finished with %d items", len(items)),
+		Data:    items,
+	}
+	s.sendJSON(w, http.StatusOK, response)
+}
+
 // Restore handler
 func (s *Server) handleRestore(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -340,29 +645,135 @@ func (s *Server) handleRestore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.WorkspaceID == "" || req.BackupPath == "" {
-		s.sendError(w, http.StatusBadRequest, "workspace_id and backup_path required")
+	if (req.WorkspaceID == "" && !req.CreateWorkspace) || req.BackupPath == "" {
+		s.sendError(w, http.StatusBadRequest, "backup_path and workspace_id or create_workspace required")
+		return
+	}
+
+	// The restore reads the backup and writes its report there, so keep it inside BACKUP_PATH
+	if _, err := s.storageService.ResolvePath(req.BackupPath); err != nil {
+		s.sendError(w, http.StatusBadRequest, fmt.Sprintf("Invalid backup_path: %v", err))
 		return
 	}
 
 	ctx := context.Background()
 
-	// Restore workspace (async)
-	go s.restoreWorkspace(ctx, req.WorkspaceID, req.BackupPath)
+	// Create the target workspace before starting the restore so its ID can be returned
+	if req.CreateWorkspace {
+		restoreService := restore.NewService(s.apiClient, s.storageService)
+		workspaceID, err := restoreService.CreateWorkspaceFromBackup(ctx, req.BackupPath, req.WorkspaceName)
+		if err != nil {
+			s.sendError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create workspace: %v", err))
+			return
+		}
+		req.WorkspaceID = workspaceID
+	}
+
+	opts := restore.Options{
+		RestoreAccess:          req.RestoreAccess,
+		RestoreItemPermissions: req.RestoreItemPermissions,
+		AssignCapacity:         req.AssignCapacity,
+		CapacityMapping:        req.CapacityMapping,
+		ApplyWorkspaceSettings: req.CreateWorkspace,
+	}
+
+	if req.Wait {
+		result, err := s.restoreWorkspace(ctx, req.WorkspaceID, req.BackupPath, opts)
+		if result == nil {
+			s.sendError(w, http.StatusInternalServerError, fmt.Sprintf("Restore failed: %v", err))
+			return
+		}
+		message := fmt.Sprintf("Restore completed for workspace: %s", req.WorkspaceID)
+		if err != nil {
+			message = fmt.Sprintf("Restore stopped for workspace %s: %v", req.WorkspaceID, err)
+		}
+		s.sendJSON(w, http.StatusOK, APIResponse{Success: err == nil, Message: message, Data: result})
+		return
+	}
+
+	// Restore workspace (async); the report is saved next to the backup, see /api/restores
+	go s.restoreWorkspace(ctx, req.WorkspaceID, req.BackupPath, opts)
 
 	response := APIResponse{
 		Success: true,
 		Message: fmt.Sprintf("Restore started for workspace: %s", req.WorkspaceID),
 		Data: map[string]interface{}{
-			"workspace_id": req.WorkspaceID,
-			"backup_path":  req.BackupPath,
-			"status":       "started",
-			"timestamp":    time.Now().Format(time.RFC3339),
+			"workspace_id":             req.WorkspaceID,
+			"backup_path":              req.BackupPath,
+			"restore_access":           req.RestoreAccess,
+			"restore_item_permissions": req.RestoreItemPermissions,
+			"assign_capacity":          req.AssignCapacity,
+			"create_workspace":         req.CreateWorkspace,
+			"status":                   "started",
+			"timestamp":                time.Now().Format(time.RFC3339),
 		},
 	}
 	s.sendJSON(w, http.StatusAccepted, response)
 }
 
+// List restores handler - restore reports saved for a backup
+func (s *Server) handleListRestores(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.sendError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	backupPath := r.URL.Query().Get("backup_path")
+	if backupPath == "" {
+		s.sendError(w, http.StatusBadRequest, "backup_path required")
+		return
+	}
+
+	if _, err := s.storageService.ResolvePath(backupPath); err != nil {
+		s.sendError(w, http.StatusBadRequest, fmt.Sprintf("Invalid backup_path: %v", err))
+		return
+	}
+
+	reports, err := s.storageService.ListRestoreReports(backupPath)
+	if err != nil {
+		s.sendError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to list restore reports: %v", err))
+		return
+	}
+
+	response := APIResponse{
+		Success: true,
+		Data:    reports,
+	}
+	s.sendJSON(w, http.StatusOK, response)
+}
+
+// restoreSummaries summarizes the restore reports saved for a backup
+func (s *Server) restoreSummaries(backupPath string) []RestoreInfo {
+	reports, err := s.storageService.ListRestoreReports(backupPath)
+	if err != nil {
+		return nil
+	}
+
+	var summaries []RestoreInfo
+	for _, report := range reports {
+		info := RestoreInfo{
+			Timestamp:         report.Timestamp,
+			TargetWorkspaceID: report.TargetWorkspaceID,
+		}
+		for _, item := range report.Items {
+			switch item.Outcome {
+			case models.ItemOutcomeRestored:
+				info.Restored++
+			case models.ItemOutcomeSkipped:
+				info.Skipped++
+				if item.Error != "" {
+					info.AttentionItems = append(info.AttentionItems, item)
+				}
+			case models.ItemOutcomeFailed:
+				info.Failed++
+				info.AttentionItems = append(info.AttentionItems, item)
+			}
+		}
+		summaries = append(summaries, info)
+	}
+	return summaries
+}
+
 // List backups handler
 func (s *Server) handleListBackups(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -378,9 +789,14 @@ func (s *Server) handleListBackups(w http.ResponseWriter, r *http.Request) {
 			return nil // Skip errors
 		}
 
+		// Skip backups that are still being written or were interrupted
+		if d.IsDir() && storage.IsStagingDir(d.Name()) {
+			return fs.SkipDir
+		}
+
 		// Check if this is a backup directory (contains complete_backup.json)
 		backupFile := filepath.Join(path, "complete_backup.json")
-		if _, statErr := os.Stat(backupFile); statErr == nil {
+		if storage.IsCompleteBackupDir(path) {
 			// Parse backup info
 			data, err := os.ReadFile(backupFile)
 			if err == nil {
@@ -424,7 +840,43 @@ func (s *Server) handleListBackups(w http.ResponseWriter, r *http.Request) {
 					if apps, ok := backupData["apps"].([]interface{}); ok {
 						info.Apps = len(apps)
 					}
+					if status, ok := backupData["status"].(string); ok {
+						info.Status = status
+					}
+					if components, ok := backupData["components"].([]interface{}); ok {
+						for _, component := range components {
+							if name, ok := component.(string); ok {
+								info.Components = append(info.Components, name)
+							}
+						}
+					}
+					if items, ok := backupData["items"].([]interface{}); ok {
+						for _, item := range items {
+							itemMap, ok := item.(map[string]interface{})
+							if !ok {
+								continue
+							}
+							switch models.ItemOutcome(getString(itemMap, "outcome")) {
+							case models.ItemOutcomeExported:
+								info.Exported++
+							case models.ItemOutcomeSkipped:
+								info.Skipped++
+							case models.ItemOutcomeFailed:
+								info.Failed++
+								attempts, _ := itemMap["attempts"].(float64)
+								info.FailedItems = append(info.FailedItems, models.ItemResult{
+									ItemType: getString(itemMap, "itemType"),
+									ItemID:   getString(itemMap, "itemId"),
+									Name:     getString(itemMap, "name"),
+									Outcome:  models.ItemOutcomeFailed,
+									Error:    getString(itemMap, "error"),
+									Attempts: int(attempts),
+								})
+							}
+						}
+					}
 
+					info.Restores = s.restoreSummaries(path)
 					backups = append(backups, info)
 				}
 			}
@@ -446,12 +898,12 @@ func (s *Server) handleListBackups(w http.ResponseWriter, r *http.Request) {
 }
 
 // Background backup operations
-func (s *Server) backupWorkspace(ctx context.Context, workspaceID string) {
+func (s *Server) backupWorkspace(ctx context.Context, workspaceID string, opts backup.Options) {
 	logger.LogInfo(fmt.Sprintf("Starting backup for workspace: %s", workspaceID))
 	start := time.Now()
 
 	backupService := backup.NewService(s.apiClient, s.storageService)
-	backupData, err := backupService.BackupWorkspace(ctx, workspaceID)
+	backupData, err := backupService.BackupWorkspace(ctx, workspaceID, opts)
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Backup failed for workspace %s", workspaceID), err)
 		return
@@ -459,68 +911,39 @@ func (s *Server) backupWorkspace(ctx context.Context, workspaceID string) {
 
 	duration := time.Since(start)
 	logger.LogInfo(fmt.Sprintf("✅ Backup completed in %v", duration))
-	logger.LogInfo(fmt.Sprintf("📊 Summary: Reports: %d, Datasets: %d, Dashboards: %d, Dataflows: %d, Apps: %d",
-		len(backupData.Reports), len(backupData.Datasets), len(backupData.Dashboards),
+	logger.LogInfo(fmt.Sprintf("📊 Summary: Status: %s, Reports: %d, Datasets: %d, Dashboards: %d, Dataflows: %d, Apps: %d",
+		backupData.Status, len(backupData.Reports), len(backupData.Datasets), len(backupData.Dashboards),
 		len(backupData.Dataflows), len(backupData.Apps)))
 }
 
-func (s *Server) backupAllWorkspaces(ctx context.Context) {
-	logger.LogInfo("Fetching all workspaces...")
-
-	workspaces, err := s.apiClient.GetWorkspaces(ctx)
-	if err != nil {
-		logger.LogError("Failed to fetch workspaces", err)
-		return
-	}
-
-	value, ok := workspaces["value"].([]interface{})
-	if !ok {
-		logger.LogError("Invalid workspace response format", nil)
-		return
-	}
-
-	logger.LogInfo(fmt.Sprintf("Found %d workspaces", len(value)))
-
+func (s *Server) backupAllWorkspaces(ctx context.Context, run *models.BackupRun) {
 	backupService := backup.NewService(s.apiClient, s.storageService)
-	successCount := 0
-	failCount := 0
-
-	for i, ws := range value {
-		wsMap, ok := ws.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		wsID := getString(wsMap, "id")
-		wsName := getString(wsMap, "name")
-
-		logger.LogInfo(fmt.Sprintf("[%d/%d] Backing up workspace: %s (%s)", i+1, len(value), wsName, wsID))
-
-		_, err := backupService.BackupWorkspace(ctx, wsID)
-		if err != nil {
-			logger.LogError(fmt.Sprintf("Failed to backup workspace: %s", wsName), err)
-			failCount++
-		} else {
-			successCount++
-		}
+	if err := backupService.RunBackup(ctx, run); err != nil {
+		logger.LogError(fmt.Sprintf("Backup run %s stopped", run.RunID), err)
 	}
-
-	logger.LogInfo(fmt.Sprintf("✅ All workspaces backup completed: %d succeeded, %d failed", successCount, failCount))
 }
 
-func (s *Server) restoreWorkspace(ctx context.Context, workspaceID, backupPath string) {
+func (s *Server) restoreWorkspace(ctx context.Context, workspaceID, backupPath string, opts restore.Options) (*models.RestoreResult, error) {
 	logger.LogInfo(fmt.Sprintf("Starting restore for workspace: %s from %s", workspaceID, backupPath))
 	start := time.Now()
 
 	restoreService := restore.NewService(s.apiClient, s.storageService)
-	err := restoreService.RestoreWorkspace(ctx, workspaceID, backupPath)
+	result, err := restoreService.RestoreWorkspace(ctx, workspaceID, backupPath, opts)
 	if err != nil {
 		logger.LogError(fmt.Sprintf("Restore failed for workspace %s", workspaceID), err)
-		return
+		return result, err
+	}
+
+	failed := 0
+	for _, item := range result.Items {
+		if item.Outcome == models.ItemOutcomeFailed {
+			failed++
+		}
 	}
 
 	duration := time.Since(start)
-	logger.LogInfo(fmt.Sprintf("✅ Restore completed in %v", duration))
+	logger.LogInfo(fmt.Sprintf("✅ Restore completed in %v: %d items, %d failed", duration, len(result.Items), failed))
+	return result, nil
 }
 
 // Helper functions
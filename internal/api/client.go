@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/veeam/powerbi-backup-go/internal/auth"
 	"github.com/veeam/powerbi-backup-go/internal/config"
@@ -18,17 +22,76 @@ import (
 
 // Client is the Power BI API client
 type Client struct {
-	authService *auth.AuthService
-	baseURL     string
-	httpClient  *http.Client
+	authService   *auth.AuthService
+	baseURL       string
+	fabricBaseURL string
+	httpClient    *http.Client
+}
+
+// APIError is returned when the Power BI API responds with an error status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Body)
+}
+
+// IsAccessDenied reports whether err is an unauthorized or forbidden API response
+func IsAccessDenied(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// IsNotFound reports whether err is a not found API response
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// IsUnsupported reports whether err is a bad request response, which the APIs
+// return for items that do not support the operation (e.g. push or Excel-based models)
+func IsUnsupported(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusBadRequest
+	}
+	return false
+}
+
+// IsTransient reports whether err may succeed on retry: throttling, server
+// errors and network failures. Other API responses, failed operations and local
+// errors (files, decoding, empty exports) will not change.
+func IsTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	var urlErr *neturl.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
 }
 
 // NewClient creates a new Power BI API client
 func NewClient(authService *auth.AuthService, settings *config.Settings) *Client {
 	return &Client{
-		authService: authService,
-		baseURL:     settings.APIBaseURL,
-		httpClient:  &http.Client{},
+		authService:   authService,
+		baseURL:       settings.APIBaseURL,
+		fabricBaseURL: settings.FabricAPIBaseURL,
+		httpClient:    &http.Client{},
 	}
 }
 
@@ -74,10 +137,15 @@ func (c *Client) fetchWithAuth(ctx context.Context, method, endpoint string, bod
 
 	if resp.StatusCode >= 400 {
 		logger.LogError(fmt.Sprintf("Error fetching %s: %d - %s", endpoint, resp.StatusCode, string(respBody)), nil)
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	// Update endpoints reply with an empty body on success
+	result := map[string]interface{}{}
+	if len(bytes.TrimSpace(respBody)) == 0 {
+		return result, nil
 	}
 
-	var result map[string]interface{}
 	if err := json.Unmarshal(respBody, &result); err != nil {
 		logger.LogError("Failed to parse response JSON", err)
 		return nil, err
@@ -91,11 +159,87 @@ func (c *Client) GetReports(ctx context.Context, workspaceID string) (map[string
 	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/reports", workspaceID), nil)
 }
 
+// GetReportPages retrieves the pages of a report
+func (c *Client) GetReportPages(ctx context.Context, workspaceID, reportID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/reports/%s/pages", workspaceID, reportID), nil)
+}
+
+// RebindReport binds a report to another dataset in the same workspace
+func (c *Client) RebindReport(ctx context.Context, workspaceID, reportID, datasetID string) error {
+	body := map[string]interface{}{"datasetId": datasetID}
+	_, err := c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/groups/%s/reports/%s/Rebind", workspaceID, reportID), body)
+	return err
+}
+
+// DeleteDataset deletes a dataset from a workspace
+func (c *Client) DeleteDataset(ctx context.Context, workspaceID, datasetID string) error {
+	_, err := c.fetchWithAuth(ctx, "DELETE", fmt.Sprintf("/groups/%s/datasets/%s", workspaceID, datasetID), nil)
+	return err
+}
+
 // GetDatasets retrieves all datasets from a workspace
 func (c *Client) GetDatasets(ctx context.Context, workspaceID string) (map[string]interface{}, error) {
 	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/datasets", workspaceID), nil)
 }
 
+// GetUpstreamDataflows retrieves the dataflows each dataset of a workspace loads from
+func (c *Client) GetUpstreamDataflows(ctx context.Context, workspaceID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/datasets/upstreamDataflows", workspaceID), nil)
+}
+
+// GetDatasetParameters retrieves the Power Query parameters of a dataset
+func (c *Client) GetDatasetParameters(ctx context.Context, workspaceID, datasetID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/datasets/%s/parameters", workspaceID, datasetID), nil)
+}
+
+// GetDatasources retrieves the datasources of a dataset
+func (c *Client) GetDatasources(ctx context.Context, workspaceID, datasetID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/datasets/%s/datasources", workspaceID, datasetID), nil)
+}
+
+// GetDatasetUsers retrieves the principals with direct access to a dataset
+func (c *Client) GetDatasetUsers(ctx context.Context, workspaceID, datasetID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/datasets/%s/users", workspaceID, datasetID), nil)
+}
+
+// AddDatasetUser grants a principal direct access to a dataset
+func (c *Client) AddDatasetUser(ctx context.Context, workspaceID, datasetID string, user map[string]interface{}) error {
+	_, err := c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/groups/%s/datasets/%s/users", workspaceID, datasetID), user)
+	return err
+}
+
+// GetReportUsersAsAdmin retrieves the principals with access to a report.
+// Requires Power BI admin API permissions.
+func (c *Client) GetReportUsersAsAdmin(ctx context.Context, reportID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/admin/reports/%s/users", reportID), nil)
+}
+
+// GetReportSubscriptionsAsAdmin retrieves the email subscriptions of a report.
+// Requires Power BI admin API permissions.
+func (c *Client) GetReportSubscriptionsAsAdmin(ctx context.Context, reportID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/admin/reports/%s/subscriptions", reportID), nil)
+}
+
+// GetDashboardSubscriptionsAsAdmin retrieves the email subscriptions of a dashboard.
+// Requires Power BI admin API permissions.
+func (c *Client) GetDashboardSubscriptionsAsAdmin(ctx context.Context, dashboardID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/admin/dashboards/%s/subscriptions", dashboardID), nil)
+}
+
+// UpdateParameters sets new values for dataset parameters
+func (c *Client) UpdateParameters(ctx context.Context, workspaceID, datasetID string, updateDetails []map[string]interface{}) error {
+	_, err := c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/groups/%s/datasets/%s/Default.UpdateParameters", workspaceID, datasetID),
+		map[string]interface{}{"updateDetails": updateDetails})
+	return err
+}
+
+// UpdateDatasources changes the connection details of dataset datasources
+func (c *Client) UpdateDatasources(ctx context.Context, workspaceID, datasetID string, updateDetails []map[string]interface{}) error {
+	_, err := c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/groups/%s/datasets/%s/Default.UpdateDatasources", workspaceID, datasetID),
+		map[string]interface{}{"updateDetails": updateDetails})
+	return err
+}
+
 // GetDataflows retrieves all dataflows from a workspace
 func (c *Client) GetDataflows(ctx context.Context, workspaceID string) (map[string]interface{}, error) {
 	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/dataflows", workspaceID), nil)
@@ -106,44 +250,147 @@ func (c *Client) GetDashboards(ctx context.Context, workspaceID string) (map[str
 	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/dashboards", workspaceID), nil)
 }
 
+// GetDashboardTiles retrieves the tiles of a dashboard
+func (c *Client) GetDashboardTiles(ctx context.Context, workspaceID, dashboardID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/dashboards/%s/tiles", workspaceID, dashboardID), nil)
+}
+
+// CreateDashboard creates an empty dashboard in a workspace
+func (c *Client) CreateDashboard(ctx context.Context, workspaceID, name string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/groups/%s/dashboards", workspaceID), map[string]interface{}{"name": name})
+}
+
+// CloneTile clones a tile of a source dashboard onto a target dashboard.
+// The request can rebind the tile to a different report or dataset.
+func (c *Client) CloneTile(ctx context.Context, workspaceID, dashboardID, tileID string, request map[string]interface{}) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/groups/%s/dashboards/%s/tiles/%s/Clone", workspaceID, dashboardID, tileID), request)
+}
+
 // GetApps retrieves all apps
 func (c *Client) GetApps(ctx context.Context) (map[string]interface{}, error) {
 	return c.fetchWithAuth(ctx, "GET", "/apps", nil)
 }
 
+// GetAppReports retrieves the reports included in an app
+func (c *Client) GetAppReports(ctx context.Context, appID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/apps/%s/reports", appID), nil)
+}
+
+// GetAppDashboards retrieves the dashboards included in an app
+func (c *Client) GetAppDashboards(ctx context.Context, appID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/apps/%s/dashboards", appID), nil)
+}
+
 // GetWorkspaceSettings retrieves workspace settings
 func (c *Client) GetWorkspaceSettings(ctx context.Context, workspaceID string) (map[string]interface{}, error) {
 	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s", workspaceID), nil)
 }
 
+// UpdateWorkspace updates a workspace's properties (name, defaultDatasetStorageFormat)
+func (c *Client) UpdateWorkspace(ctx context.Context, workspaceID string, update map[string]interface{}) error {
+	_, err := c.fetchWithAuth(ctx, "PATCH", fmt.Sprintf("/groups/%s", workspaceID), update)
+	return err
+}
+
+// AssignToDataflowStorage assigns a workspace to a dataflow storage account
+func (c *Client) AssignToDataflowStorage(ctx context.Context, workspaceID, dataflowStorageID string) error {
+	body := map[string]interface{}{"dataflowStorageId": dataflowStorageID}
+	_, err := c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/groups/%s/AssignToDataflowStorage", workspaceID), body)
+	return err
+}
+
+// AssignToCapacity assigns a workspace to a capacity; an empty capacity ID
+// moves the workspace back to shared capacity. The assignment completes
+// asynchronously, see GetCapacityAssignmentStatus.
+func (c *Client) AssignToCapacity(ctx context.Context, workspaceID, capacityID string) error {
+	if capacityID == "" {
+		capacityID = "00000000-0000-0000-0000-000000000000"
+	}
+	body := map[string]interface{}{"capacityId": capacityID}
+	_, err := c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/groups/%s/AssignToCapacity", workspaceID), body)
+	return err
+}
+
+// GetCapacityAssignmentStatus retrieves the status of a workspace's capacity assignment
+func (c *Client) GetCapacityAssignmentStatus(ctx context.Context, workspaceID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/CapacityAssignmentStatus", workspaceID), nil)
+}
+
+// GetWorkspaceUsers retrieves the users, groups and apps with access to a workspace
+func (c *Client) GetWorkspaceUsers(ctx context.Context, workspaceID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/users", workspaceID), nil)
+}
+
+// AddWorkspaceUser grants a principal access to a workspace
+func (c *Client) AddWorkspaceUser(ctx context.Context, workspaceID string, user map[string]interface{}) error {
+	_, err := c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/groups/%s/users", workspaceID), user)
+	return err
+}
+
+// UpdateWorkspaceUser changes the role of a principal in a workspace
+func (c *Client) UpdateWorkspaceUser(ctx context.Context, workspaceID string, user map[string]interface{}) error {
+	_, err := c.fetchWithAuth(ctx, "PUT", fmt.Sprintf("/groups/%s/users", workspaceID), user)
+	return err
+}
+
 // GetRefreshSchedule retrieves the refresh schedule for a dataset
 func (c *Client) GetRefreshSchedule(ctx context.Context, workspaceID, datasetID string) (map[string]interface{}, error) {
 	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/datasets/%s/refreshSchedule", workspaceID, datasetID), nil)
 }
 
+// GetDirectQueryRefreshSchedule retrieves the cache refresh schedule of a DirectQuery or LiveConnection dataset
+func (c *Client) GetDirectQueryRefreshSchedule(ctx context.Context, workspaceID, datasetID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/datasets/%s/directQueryRefreshSchedule", workspaceID, datasetID), nil)
+}
+
+// GetRefreshHistory retrieves the most recent refreshes of a dataset
+func (c *Client) GetRefreshHistory(ctx context.Context, workspaceID, datasetID string, top int) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/datasets/%s/refreshes?$top=%d", workspaceID, datasetID, top), nil)
+}
+
 // ExportReport exports a report as a PBIX file
 // Uses the simple /Export endpoint that returns the PBIX directly
 func (c *Client) ExportReport(ctx context.Context, workspaceID, reportID, outputPath string) (bool, error) {
+	// Direct export endpoint - GET returns PBIX file directly
+	if err := c.downloadFile(ctx, fmt.Sprintf("/groups/%s/reports/%s/Export", workspaceID, reportID), outputPath); err != nil {
+		return false, err
+	}
+
+	logger.LogInfo(fmt.Sprintf("✅ Exported report to: %s", outputPath))
+	return true, nil
+}
+
+// ExportDataflow downloads the definition (model.json) of a dataflow
+func (c *Client) ExportDataflow(ctx context.Context, workspaceID, dataflowID, outputPath string) error {
+	if err := c.downloadFile(ctx, fmt.Sprintf("/groups/%s/dataflows/%s", workspaceID, dataflowID), outputPath); err != nil {
+		return err
+	}
+
+	logger.LogInfo(fmt.Sprintf("✅ Exported dataflow to: %s", outputPath))
+	return nil
+}
+
+// downloadFile streams the response body of an authenticated GET request to a file
+func (c *Client) downloadFile(ctx context.Context, endpoint, outputPath string) error {
 	token, err := c.authService.GetAccessToken(ctx)
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	// Direct export endpoint - GET returns PBIX file directly
-	exportURL := fmt.Sprintf("%s/groups/%s/reports/%s/Export", c.baseURL, workspaceID, reportID)
-	logger.LogInfo(fmt.Sprintf("✅ URL %s", exportURL))
-	req, err := http.NewRequestWithContext(ctx, "GET", exportURL, nil)
+	downloadURL := fmt.Sprintf("%s%s", c.baseURL, endpoint)
+	logger.LogInfo(fmt.Sprintf("✅ URL %s", downloadURL))
+	req, err := http.NewRequestWithContext(ctx, "GET", downloadURL, nil)
 	if err != nil {
 		logger.LogError("Failed to create export request", err)
-		return false, err
+		return err
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		logger.LogError("Failed to export report", err)
-		return false, err
+		logger.LogError(fmt.Sprintf("Failed to export %s", endpoint), err)
+		return err
 	}
 	defer resp.Body.Close()
 
@@ -151,39 +398,102 @@ func (c *Client) ExportReport(ctx context.Context, workspaceID, reportID, output
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
 		logger.LogError(fmt.Sprintf("Export failed: %d - %s", resp.StatusCode, string(respBody)), nil)
-		return false, fmt.Errorf("export failed: status %d", resp.StatusCode)
+		return &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
-	// Write the PBIX file to disk
-	file, err := os.Create(outputPath)
+	// Write to a partial file first so an interrupted download never looks complete
+	partPath := outputPath + ".part"
+	file, err := os.Create(partPath)
 	if err != nil {
-		logger.LogError(fmt.Sprintf("Failed to create output file: %s", outputPath), err)
-		return false, err
+		logger.LogError(fmt.Sprintf("Failed to create output file: %s", partPath), err)
+		return err
 	}
-	defer file.Close()
 
 	_, err = io.Copy(file, resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
-		logger.LogError("Failed to write PBIX file", err)
-		return false, err
+		logger.LogError(fmt.Sprintf("Failed to write file: %s", outputPath), err)
+		os.Remove(partPath)
+		return err
 	}
 
-	logger.LogInfo(fmt.Sprintf("✅ Exported report to: %s", outputPath))
-	return true, nil
+	return os.Rename(partPath, outputPath)
+}
+
+// ImportPBIX imports a PBIX file to a workspace and returns the import ID
+func (c *Client) ImportPBIX(ctx context.Context, workspaceID, pbixPath, datasetName string) (string, error) {
+	params := neturl.Values{}
+	params.Set("datasetDisplayName", datasetName)
+	params.Set("nameConflict", "Abort")
+
+	result, err := c.importFile(ctx, workspaceID, pbixPath, filepath.Base(pbixPath), params)
+	if err != nil {
+		return "", err
+	}
+
+	logger.LogInfo(fmt.Sprintf("PBIX import queued successfully: %s", datasetName))
+	importID, _ := result["id"].(string)
+	return importID, nil
+}
+
+// ImportRDL imports a paginated report (.rdl) to a workspace and returns the import ID
+func (c *Client) ImportRDL(ctx context.Context, workspaceID, rdlPath, reportName string) (string, error) {
+	// Paginated report imports require the .rdl extension on the display name
+	displayName := reportName
+	if !strings.HasSuffix(strings.ToLower(displayName), ".rdl") {
+		displayName += ".rdl"
+	}
+
+	params := neturl.Values{}
+	params.Set("datasetDisplayName", displayName)
+	params.Set("nameConflict", "Abort")
+
+	result, err := c.importFile(ctx, workspaceID, rdlPath, filepath.Base(rdlPath), params)
+	if err != nil {
+		return "", err
+	}
+
+	logger.LogInfo(fmt.Sprintf("RDL import queued successfully: %s", displayName))
+	importID, _ := result["id"].(string)
+	return importID, nil
+}
+
+// GetImport retrieves the state of an import, including the items it created
+func (c *Client) GetImport(ctx context.Context, workspaceID, importID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/imports/%s", workspaceID, importID), nil)
+}
+
+// ImportDataflow imports a dataflow definition (model.json) to a workspace.
+// Returns the import response, which holds the import ID.
+func (c *Client) ImportDataflow(ctx context.Context, workspaceID, definitionPath string) (map[string]interface{}, error) {
+	// Dataflow imports are recognised by the model.json display name
+	params := neturl.Values{}
+	params.Set("datasetDisplayName", "model.json")
+	params.Set("nameConflict", "GenerateUniqueName")
+
+	result, err := c.importFile(ctx, workspaceID, definitionPath, "model.json", params)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.LogInfo(fmt.Sprintf("Dataflow import queued successfully: %s", definitionPath))
+	return result, nil
 }
 
-// ImportPBIX imports a PBIX file to a workspace
-func (c *Client) ImportPBIX(ctx context.Context, workspaceID, pbixPath, datasetName string) (bool, error) {
+// importFile uploads a file to the imports endpoint as a multipart form
+func (c *Client) importFile(ctx context.Context, workspaceID, filePath, formFileName string, params neturl.Values) (map[string]interface{}, error) {
 	token, err := c.authService.GetAccessToken(ctx)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	// Open the PBIX file
-	file, err := os.Open(pbixPath)
+	// Open the file
+	file, err := os.Open(filePath)
 	if err != nil {
-		logger.LogError(fmt.Sprintf("Failed to open PBIX file: %s", pbixPath), err)
-		return false, err
+		logger.LogError(fmt.Sprintf("Failed to open import file: %s", filePath), err)
+		return nil, err
 	}
 	defer file.Close()
 
@@ -192,28 +502,27 @@ func (c *Client) ImportPBIX(ctx context.Context, workspaceID, pbixPath, datasetN
 	writer := multipart.NewWriter(body)
 
 	// Add file part
-	part, err := writer.CreateFormFile("file", filepath.Base(pbixPath))
+	part, err := writer.CreateFormFile("file", formFileName)
 	if err != nil {
 		logger.LogError("Failed to create form file", err)
-		return false, err
+		return nil, err
 	}
 
 	_, err = io.Copy(part, file)
 	if err != nil {
 		logger.LogError("Failed to copy file to form", err)
-		return false, err
+		return nil, err
 	}
 
 	writer.Close()
 
 	// Create request
-	url := fmt.Sprintf("%s/groups/%s/imports?datasetDisplayName=%s&nameConflict=Abort", 
-		c.baseURL, workspaceID, datasetName)
+	url := fmt.Sprintf("%s/groups/%s/imports?%s", c.baseURL, workspaceID, params.Encode())
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
 	if err != nil {
 		logger.LogError("Failed to create import request", err)
-		return false, err
+		return nil, err
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
@@ -221,24 +530,95 @@ func (c *Client) ImportPBIX(ctx context.Context, workspaceID, pbixPath, datasetN
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		logger.LogError("Failed to import PBIX", err)
-		return false, err
+		logger.LogError(fmt.Sprintf("Failed to import %s", filePath), err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
+	respBody, _ := io.ReadAll(resp.Body)
+
 	if resp.StatusCode == http.StatusAccepted || resp.StatusCode == http.StatusOK {
-		logger.LogInfo(fmt.Sprintf("PBIX import queued successfully: %s", datasetName))
-		return true, nil
+		result := map[string]interface{}{}
+		if len(respBody) > 0 {
+			if err := json.Unmarshal(respBody, &result); err != nil {
+				logger.LogWarn(fmt.Sprintf("Failed to parse import response: %v", err))
+			}
+		}
+		return result, nil
 	}
 
-	respBody, _ := io.ReadAll(resp.Body)
-	logger.LogError(fmt.Sprintf("PBIX import failed: %d - %s", resp.StatusCode, string(respBody)), nil)
-	return false, fmt.Errorf("import failed: %d - %s", resp.StatusCode, string(respBody))
+	logger.LogError(fmt.Sprintf("Import failed: %d - %s", resp.StatusCode, string(respBody)), nil)
+	return nil, fmt.Errorf("import failed: %d - %s", resp.StatusCode, string(respBody))
 }
 
 // UpdateRefreshSchedule updates the refresh schedule for a dataset
 func (c *Client) UpdateRefreshSchedule(ctx context.Context, workspaceID, datasetID string, schedule map[string]interface{}) error {
-	_, err := c.fetchWithAuth(ctx, "PATCH", fmt.Sprintf("/groups/%s/datasets/%s/refreshSchedule", workspaceID, datasetID), schedule)
+	body := map[string]interface{}{"value": schedule}
+	_, err := c.fetchWithAuth(ctx, "PATCH", fmt.Sprintf("/groups/%s/datasets/%s/refreshSchedule", workspaceID, datasetID), body)
+	return err
+}
+
+// UpdateDirectQueryRefreshSchedule updates the cache refresh schedule of a DirectQuery or LiveConnection dataset
+func (c *Client) UpdateDirectQueryRefreshSchedule(ctx context.Context, workspaceID, datasetID string, schedule map[string]interface{}) error {
+	body := map[string]interface{}{"value": schedule}
+	_, err := c.fetchWithAuth(ctx, "PATCH", fmt.Sprintf("/groups/%s/datasets/%s/directQueryRefreshSchedule", workspaceID, datasetID), body)
+	return err
+}
+
+// GetGateways retrieves the gateways the principal is an admin of
+func (c *Client) GetGateways(ctx context.Context) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", "/gateways", nil)
+}
+
+// GetGatewayDatasources retrieves the datasources configured on a gateway
+func (c *Client) GetGatewayDatasources(ctx context.Context, gatewayID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/gateways/%s/datasources", gatewayID), nil)
+}
+
+// GetBoundGatewayDatasources retrieves the gateway datasources a dataset is bound to
+func (c *Client) GetBoundGatewayDatasources(ctx context.Context, workspaceID, datasetID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/groups/%s/datasets/%s/Default.GetBoundGatewayDatasources", workspaceID, datasetID), nil)
+}
+
+// GetPipelines retrieves the deployment pipelines the principal has access to
+func (c *Client) GetPipelines(ctx context.Context) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", "/pipelines", nil)
+}
+
+// GetPipelineStages retrieves the stages of a deployment pipeline
+func (c *Client) GetPipelineStages(ctx context.Context, pipelineID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/pipelines/%s/stages", pipelineID), nil)
+}
+
+// GetPipelineOperations retrieves the operations history of a deployment pipeline
+func (c *Client) GetPipelineOperations(ctx context.Context, pipelineID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/pipelines/%s/operations", pipelineID), nil)
+}
+
+// GetPipelineUsers retrieves the principals with access to a deployment pipeline
+func (c *Client) GetPipelineUsers(ctx context.Context, pipelineID string) (map[string]interface{}, error) {
+	return c.fetchWithAuth(ctx, "GET", fmt.Sprintf("/pipelines/%s/users", pipelineID), nil)
+}
+
+// UpdatePipelineUser grants a principal access to a deployment pipeline
+func (c *Client) UpdatePipelineUser(ctx context.Context, pipelineID string, user map[string]interface{}) error {
+	_, err := c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/pipelines/%s/users", pipelineID), user)
+	return err
+}
+
+// CreatePipeline creates a deployment pipeline and returns it
+func (c *Client) CreatePipeline(ctx context.Context, displayName, description string) (map[string]interface{}, error) {
+	body := map[string]interface{}{"displayName": displayName}
+	if description != "" {
+		body["description"] = description
+	}
+	return c.fetchWithAuth(ctx, "POST", "/pipelines", body)
+}
+
+// AssignPipelineStageWorkspace assigns a workspace to a deployment pipeline stage
+func (c *Client) AssignPipelineStageWorkspace(ctx context.Context, pipelineID string, stageOrder int, workspaceID string) error {
+	body := map[string]interface{}{"workspaceId": workspaceID}
+	_, err := c.fetchWithAuth(ctx, "POST", fmt.Sprintf("/pipelines/%s/stages/%d/assignWorkspace", pipelineID, stageOrder), body)
 	return err
 }
 
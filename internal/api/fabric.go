@@ -0,0 +1,252 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"time"
+
+	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
+)
+
+// OperationError is returned when a Fabric long running operation ends in failure
+type OperationError struct {
+	Body string
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("operation failed: %s", e.Body)
+}
+
+const (
+	// operationTimeout bounds how long a Fabric long running operation is awaited
+	operationTimeout = 10 * time.Minute
+	// defaultRetryAfter is used when a long running operation gives no Retry-After
+	defaultRetryAfter = 5 * time.Second
+)
+
+// GetItems retrieves all Fabric items in a workspace, following continuation tokens
+func (c *Client) GetItems(ctx context.Context, workspaceID string) ([]map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("/workspaces/%s/items", workspaceID)
+
+	var items []map[string]interface{}
+	continuationToken := ""
+	for {
+		pageEndpoint := endpoint
+		if continuationToken != "" {
+			pageEndpoint += "?continuationToken=" + neturl.QueryEscape(continuationToken)
+		}
+
+		result, err := c.fabricWithAuth(ctx, "GET", pageEndpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		value, _ := result["value"].([]interface{})
+		for _, item := range value {
+			if itemMap, ok := item.(map[string]interface{}); ok {
+				items = append(items, itemMap)
+			}
+		}
+
+		continuationToken, _ = result["continuationToken"].(string)
+		if continuationToken == "" {
+			return items, nil
+		}
+	}
+}
+
+// GetItemDefinition retrieves the definition parts of a Fabric item.
+// Format selects the definition format (e.g. TMDL or TMSL for semantic models);
+// an empty format uses the item type's default.
+func (c *Client) GetItemDefinition(ctx context.Context, workspaceID, itemID, format string) ([]models.DefinitionPart, error) {
+	endpoint := fmt.Sprintf("/workspaces/%s/items/%s/getDefinition", workspaceID, itemID)
+	if format != "" {
+		endpoint += "?format=" + format
+	}
+
+	result, err := c.fabricWithAuth(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, _ := result["definition"].(map[string]interface{})
+	rawParts, _ := definition["parts"].([]interface{})
+
+	parts := make([]models.DefinitionPart, 0, len(rawParts))
+	for _, rawPart := range rawParts {
+		partMap, ok := rawPart.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		path, _ := partMap["path"].(string)
+		payload, _ := partMap["payload"].(string)
+		decoded, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payload for definition part %s: %w", path, err)
+		}
+
+		parts = append(parts, models.DefinitionPart{Path: path, Payload: decoded})
+	}
+
+	return parts, nil
+}
+
+// CreateItem creates a Fabric item of the given type from its definition parts
+// and returns the created item. Without parts an empty item is created.
+func (c *Client) CreateItem(ctx context.Context, workspaceID, displayName, itemType string, parts []models.DefinitionPart) (map[string]interface{}, error) {
+	encodedParts := make([]map[string]interface{}, 0, len(parts))
+	for _, part := range parts {
+		encodedParts = append(encodedParts, map[string]interface{}{
+			"path":        part.Path,
+			"payload":     base64.StdEncoding.EncodeToString(part.Payload),
+			"payloadType": "InlineBase64",
+		})
+	}
+
+	body := map[string]interface{}{
+		"displayName": displayName,
+		"type":        itemType,
+	}
+	if len(encodedParts) > 0 {
+		body["definition"] = map[string]interface{}{"parts": encodedParts}
+	}
+
+	return c.fabricWithAuth(ctx, "POST", fmt.Sprintf("/workspaces/%s/items", workspaceID), body)
+}
+
+// fabricWithAuth makes an authenticated request to the Fabric API. Requests the
+// API accepts asynchronously (202) are followed until the operation completes,
+// returning the operation result. The Power BI token is accepted by Fabric.
+func (c *Client) fabricWithAuth(ctx context.Context, method, endpoint string, body interface{}) (map[string]interface{}, error) {
+	resp, respBody, err := c.fabricRequest(ctx, method, c.fabricBaseURL+endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return parseFabricBody(respBody)
+	}
+
+	// Long running operation - poll the operation until it finishes
+	operationURL := resp.Header.Get("Location")
+	if operationURL == "" {
+		return map[string]interface{}{}, nil
+	}
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+	deadline := time.Now().Add(operationTimeout)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryAfter):
+		}
+
+		resp, respBody, err = c.fabricRequest(ctx, "GET", operationURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		operation, err := parseFabricBody(respBody)
+		if err != nil {
+			return nil, err
+		}
+
+		switch status, _ := operation["status"].(string); status {
+		case "Succeeded":
+			// Operations with a result point at it through the Location header
+			resultURL := resp.Header.Get("Location")
+			if resultURL == "" {
+				return operation, nil
+			}
+			_, respBody, err = c.fabricRequest(ctx, "GET", resultURL, nil)
+			if err != nil {
+				return nil, err
+			}
+			return parseFabricBody(respBody)
+		case "Failed", "Undefined":
+			logger.LogError(fmt.Sprintf("Fabric operation failed for %s: %s", endpoint, string(respBody)), nil)
+			return nil, &OperationError{Body: string(respBody)}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("operation for %s did not finish within %v", endpoint, operationTimeout)
+		}
+		retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+}
+
+// fabricRequest sends a single authenticated request to an absolute Fabric URL
+func (c *Client) fabricRequest(ctx context.Context, method, url string, body interface{}) (*http.Response, []byte, error) {
+	token, err := c.authService.GetAccessToken(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		reqBody = bytes.NewBuffer(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to create request for %s", url), err)
+		return nil, nil, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to fetch %s", url), err)
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.LogError("Failed to read response body", err)
+		return nil, nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		logger.LogError(fmt.Sprintf("Error fetching %s: %d - %s", url, resp.StatusCode, string(respBody)), nil)
+		return nil, nil, &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	return resp, respBody, nil
+}
+
+func parseFabricBody(body []byte) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return result, nil
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		logger.LogError("Failed to parse response JSON", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultRetryAfter
+}
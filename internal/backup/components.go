@@ -0,0 +1,120 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Components that can be selected for a backup
+const (
+	ComponentReports       = "reports"       // Report metadata and pages
+	ComponentDatasets      = "datasets"      // Dataset metadata, connections and refresh history
+	ComponentDataflows     = "dataflows"     // Dataflow metadata
+	ComponentItems         = "items"         // Fabric items such as notebooks, lakehouses and data pipelines
+	ComponentDashboards    = "dashboards"    // Dashboards and their tiles
+	ComponentApps          = "apps"          // Apps published from the workspace
+	ComponentUsers         = "users"         // Workspace roles
+	ComponentPermissions   = "permissions"   // Direct dataset and report access
+	ComponentSubscriptions = "subscriptions" // Report and dashboard subscriptions
+	ComponentSchedules     = "schedules"     // Refresh schedules
+	ComponentDefinitions   = "definitions"   // Semantic model, dataflow and Fabric item definition files
+	ComponentPBIX          = "pbix"          // Report PBIX and RDL files
+)
+
+// AllComponents is the default selection, backing up everything
+var AllComponents = []string{
+	ComponentReports,
+	ComponentDatasets,
+	ComponentDataflows,
+	ComponentItems,
+	ComponentDashboards,
+	ComponentApps,
+	ComponentUsers,
+	ComponentPermissions,
+	ComponentSubscriptions,
+	ComponentSchedules,
+	ComponentDefinitions,
+	ComponentPBIX,
+}
+
+// fileComponents export item files rather than metadata
+var fileComponents = map[string]bool{
+	ComponentDefinitions: true,
+	ComponentPBIX:        true,
+}
+
+// Options selects what a backup includes
+type Options struct {
+	// Components to back up; empty backs up all components
+	Components []string
+	// StagingTime resumes an interrupted backup in its staging directory;
+	// zero starts a new backup
+	StagingTime time.Time
+	// ExportedFiles lists the report files already exported into the staging
+	// directory by ID and size; a file is reused only when its size still matches
+	ExportedFiles map[string]int64
+	// OnExported is called after each report file is exported, so the caller
+	// can checkpoint it
+	OnExported func(itemID string, size int64)
+}
+
+// ResolveComponents validates a component selection. An empty selection means
+// all components; metadataOnly drops the components that export item files.
+func ResolveComponents(components []string, metadataOnly bool) ([]string, error) {
+	if len(components) == 0 {
+		components = AllComponents
+	}
+
+	selected := make(map[string]bool, len(components))
+	for _, component := range components {
+		component = strings.ToLower(strings.TrimSpace(component))
+		if !isComponent(component) {
+			return nil, fmt.Errorf("unknown backup component %q (valid: %s)", component, strings.Join(AllComponents, ", "))
+		}
+		selected[component] = true
+	}
+
+	// Keep the canonical order so backups record the selection consistently
+	resolved := make([]string, 0, len(selected))
+	for _, component := range AllComponents {
+		if selected[component] && !(metadataOnly && fileComponents[component]) {
+			resolved = append(resolved, component)
+		}
+	}
+
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("no backup components selected")
+	}
+	return resolved, nil
+}
+
+func isComponent(name string) bool {
+	for _, component := range AllComponents {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
+
+// componentSet is the resolved selection of a backup run
+type componentSet map[string]bool
+
+func newComponentSet(components []string) componentSet {
+	set := make(componentSet, len(components))
+	for _, component := range components {
+		set[component] = true
+	}
+	return set
+}
+
+// any reports whether at least one of the components is selected
+func (c componentSet) any(components ...string) bool {
+	for _, component := range components {
+		if c[component] {
+			return true
+		}
+	}
+	return false
+}
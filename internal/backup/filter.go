@@ -0,0 +1,116 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
+)
+
+// defaultWorkspaceState is assumed for workspaces listed without a state;
+// the non-admin workspaces API only returns active workspaces
+const defaultWorkspaceState = "Active"
+
+// WorkspaceFilter selects the workspaces included in a tenant-wide backup.
+// Empty fields do not restrict the selection; excluded IDs always win.
+type WorkspaceFilter struct {
+	NameRegex        string   `json:"name_regex,omitempty"`
+	ExcludeNameRegex string   `json:"exclude_name_regex,omitempty"`
+	Types            []string `json:"types,omitempty"`
+	CapacityIDs      []string `json:"capacity_ids,omitempty"`
+	States           []string `json:"states,omitempty"`
+	IncludeIDs       []string `json:"include_ids,omitempty"`
+	ExcludeIDs       []string `json:"exclude_ids,omitempty"`
+}
+
+// SelectWorkspaces lists the workspaces visible to the principal and returns
+// the ones matching the filter
+func (s *Service) SelectWorkspaces(ctx context.Context, filter WorkspaceFilter) ([]models.WorkspaceRef, error) {
+	include, err := compileFilterRegex(filter.NameRegex)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compileFilterRegex(filter.ExcludeNameRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.apiClient.GetWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	value, _ := response["value"].([]interface{})
+	selected := make([]models.WorkspaceRef, 0, len(value))
+
+	for _, item := range value {
+		workspaceMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		workspace := models.WorkspaceRef{
+			ID:         getString(workspaceMap, "id"),
+			Name:       getString(workspaceMap, "name"),
+			Type:       getString(workspaceMap, "type"),
+			State:      getString(workspaceMap, "state"),
+			CapacityID: getString(workspaceMap, "capacityId"),
+		}
+		if workspace.State == "" {
+			workspace.State = defaultWorkspaceState
+		}
+
+		if reason := filter.reject(workspace, include, exclude); reason != "" {
+			logger.LogDebug(fmt.Sprintf("Excluding workspace %s (%s): %s", workspace.Name, workspace.ID, reason))
+			continue
+		}
+		selected = append(selected, workspace)
+	}
+
+	logger.LogInfo(fmt.Sprintf("Selected %d of %d workspaces", len(selected), len(value)))
+	return selected, nil
+}
+
+// reject returns why a workspace is filtered out, or an empty string if it is selected
+func (f WorkspaceFilter) reject(workspace models.WorkspaceRef, include, exclude *regexp.Regexp) string {
+	switch {
+	case containsFold(f.ExcludeIDs, workspace.ID):
+		return "ID is excluded"
+	case len(f.IncludeIDs) > 0 && !containsFold(f.IncludeIDs, workspace.ID):
+		return "ID is not included"
+	case include != nil && !include.MatchString(workspace.Name):
+		return "name does not match"
+	case exclude != nil && exclude.MatchString(workspace.Name):
+		return "name is excluded"
+	case len(f.Types) > 0 && !containsFold(f.Types, workspace.Type):
+		return fmt.Sprintf("type %q is not selected", workspace.Type)
+	case len(f.CapacityIDs) > 0 && !containsFold(f.CapacityIDs, workspace.CapacityID):
+		return "capacity is not selected"
+	case len(f.States) > 0 && !containsFold(f.States, workspace.State):
+		return fmt.Sprintf("state %q is not selected", workspace.State)
+	}
+	return ""
+}
+
+func compileFilterRegex(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace name pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
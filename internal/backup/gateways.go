@@ -0,0 +1,158 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
+)
+
+// BackupGateways saves a tenant-level inventory of the gateways the principal
+// administers, their datasources and the bindings of the datasets in the given
+// workspaces. Credentials are not returned by the API and are never stored.
+func (s *Service) BackupGateways(ctx context.Context, workspaces []models.WorkspaceRef) (*models.GatewayInventory, string, error) {
+	logger.LogInfo("Starting gateway inventory backup")
+
+	inventory := &models.GatewayInventory{
+		Timestamp: time.Now(),
+		Items:     []models.ItemResult{},
+		Gateways:  []models.Gateway{},
+		Bindings:  []models.DatasourceBinding{},
+	}
+
+	logger.LogInfo("Backing up gateways...")
+	response, err := s.apiClient.GetGateways(ctx)
+	if err != nil {
+		logger.LogError("Failed to list gateways", err)
+		inventory.Items = append(inventory.Items, componentFailure("gateways", err))
+	} else {
+		value, _ := response["value"].([]interface{})
+		for _, item := range value {
+			gatewayMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			gateway := models.Gateway{
+				ID:          getString(gatewayMap, "id"),
+				Name:        getString(gatewayMap, "name"),
+				Type:        getString(gatewayMap, "type"),
+				Annotation:  getString(gatewayMap, "gatewayAnnotation"),
+				Datasources: []models.GatewayDatasource{},
+			}
+			inventory.Items = append(inventory.Items, s.backupGatewayDatasources(ctx, &gateway))
+			inventory.Gateways = append(inventory.Gateways, gateway)
+		}
+		logger.LogInfo(fmt.Sprintf("Successfully backed up %d gateways", len(inventory.Gateways)))
+	}
+
+	logger.LogInfo("Backing up dataset bindings...")
+	for _, ws := range workspaces {
+		bindings, results := s.backupDatasourceBindings(ctx, ws)
+		inventory.Bindings = append(inventory.Bindings, bindings...)
+		inventory.Items = append(inventory.Items, results...)
+	}
+	logger.LogInfo(fmt.Sprintf("Successfully backed up %d dataset bindings", len(inventory.Bindings)))
+
+	inventory.Status = summarizeStatus(inventory.Items)
+
+	path, err := s.storageService.SaveGatewayInventory(inventory)
+	if err != nil {
+		logger.LogError("Failed to save gateway inventory", err)
+		return nil, "", err
+	}
+
+	return inventory, path, nil
+}
+
+// backupGatewayDatasources fills in the datasources configured on a gateway
+func (s *Service) backupGatewayDatasources(ctx context.Context, gateway *models.Gateway) models.ItemResult {
+	result := models.ItemResult{
+		ItemType: models.ItemTypeGateway,
+		ItemID:   gateway.ID,
+		Name:     gateway.Name,
+		Attempts: 1,
+	}
+
+	response, err := s.apiClient.GetGatewayDatasources(ctx, gateway.ID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to get datasources of gateway: %s", gateway.Name), err)
+		result.Outcome = models.ItemOutcomeFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	value, _ := response["value"].([]interface{})
+	for _, item := range value {
+		datasourceMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		gateway.Datasources = append(gateway.Datasources, models.GatewayDatasource{
+			ID:                getString(datasourceMap, "id"),
+			GatewayID:         gateway.ID,
+			DatasourceName:    getString(datasourceMap, "datasourceName"),
+			DatasourceType:    getString(datasourceMap, "datasourceType"),
+			ConnectionDetails: getString(datasourceMap, "connectionDetails"),
+			CredentialType:    getString(datasourceMap, "credentialType"),
+		})
+	}
+
+	result.Outcome = models.ItemOutcomeExported
+	return result
+}
+
+// backupDatasourceBindings records the gateway datasources each dataset of a workspace is bound to
+func (s *Service) backupDatasourceBindings(ctx context.Context, ws models.WorkspaceRef) ([]models.DatasourceBinding, []models.ItemResult) {
+	bindings := []models.DatasourceBinding{}
+
+	datasets, err := s.backupDatasets(ctx, ws.ID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to list datasets of workspace: %s", ws.Name), err)
+		return bindings, []models.ItemResult{componentFailure(fmt.Sprintf("datasets of %s", ws.ID), err)}
+	}
+
+	results := make([]models.ItemResult, 0, len(datasets))
+	for _, dataset := range datasets {
+		result := models.ItemResult{
+			ItemType: models.ItemTypeDatasourceBinding,
+			ItemID:   dataset.ID,
+			Name:     dataset.Name,
+			Attempts: 1,
+		}
+
+		response, err := s.apiClient.GetBoundGatewayDatasources(ctx, ws.ID, dataset.ID)
+		if err != nil {
+			logger.LogWarn(fmt.Sprintf("Failed to get gateway bindings of dataset %s: %v", dataset.Name, err))
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		value, _ := response["value"].([]interface{})
+		for _, item := range value {
+			datasourceMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			bindings = append(bindings, models.DatasourceBinding{
+				WorkspaceID:       ws.ID,
+				WorkspaceName:     ws.Name,
+				DatasetID:         dataset.ID,
+				DatasetName:       dataset.Name,
+				GatewayID:         getString(datasourceMap, "gatewayId"),
+				DatasourceID:      getString(datasourceMap, "id"),
+				DatasourceType:    getString(datasourceMap, "datasourceType"),
+				ConnectionDetails: getString(datasourceMap, "connectionDetails"),
+			})
+		}
+
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
+	}
+
+	return bindings, results
+}
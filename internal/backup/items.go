@@ -0,0 +1,137 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/veeam/powerbi-backup-go/internal/api"
+	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
+	"github.com/veeam/powerbi-backup-go/internal/storage"
+)
+
+// powerBIItemTypes are Fabric item types the Power BI backup already covers, or
+// that the service creates alongside another item (SQL endpoints of lakehouses)
+var powerBIItemTypes = map[string]bool{
+	"Report":          true,
+	"PaginatedReport": true,
+	"SemanticModel":   true,
+	"Dashboard":       true,
+	"Dataflow":        true,
+	"Datamart":        true,
+	"SQLEndpoint":     true,
+}
+
+// definitionItemTypes are the Fabric item types whose definition can be exported
+// with getDefinition and recreated with createItem
+var definitionItemTypes = map[string]bool{
+	"Notebook":           true,
+	"DataPipeline":       true,
+	"SparkJobDefinition": true,
+	"Lakehouse":          true,
+	"Environment":        true,
+	"Eventhouse":         true,
+	"Eventstream":        true,
+	"KQLDatabase":        true,
+	"KQLQueryset":        true,
+	"KQLDashboard":       true,
+	"MirroredDatabase":   true,
+	"Reflex":             true,
+	"GraphQLApi":         true,
+	"CopyJob":            true,
+	"VariableLibrary":    true,
+}
+
+// backupFabricItems lists the Fabric items of a workspace that the Power BI endpoints don't return
+func (s *Service) backupFabricItems(ctx context.Context, workspaceID string) ([]models.FabricItem, error) {
+	response, err := s.apiClient.GetItems(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []models.FabricItem{}
+	for _, itemMap := range response {
+		itemType := getString(itemMap, "type")
+		if powerBIItemTypes[itemType] {
+			continue
+		}
+
+		items = append(items, models.FabricItem{
+			ID:          getString(itemMap, "id"),
+			DisplayName: getString(itemMap, "displayName"),
+			Description: getString(itemMap, "description"),
+			Type:        itemType,
+		})
+	}
+
+	return items, nil
+}
+
+// backupFabricItemDefinitions saves the definition parts of each Fabric item
+// whose type supports definitions to items/{name}_{id}/
+func (s *Service) backupFabricItemDefinitions(ctx context.Context, workspaceID string, items []models.FabricItem, backupDir string) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(items))
+
+	for i := range items {
+		item := &items[i]
+
+		result := models.ItemResult{
+			ItemType: models.ItemTypeFabricItem,
+			ItemID:   item.ID,
+			Name:     item.DisplayName,
+		}
+
+		if !definitionItemTypes[item.Type] {
+			logger.LogWarn(fmt.Sprintf("⚠️  %s items have no exportable definition: %s", item.Type, item.DisplayName))
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = fmt.Sprintf("%s items have no exportable definition", item.Type)
+			results = append(results, result)
+			continue
+		}
+
+		logger.LogInfo(fmt.Sprintf("📥 Exporting %s definition: %s", item.Type, item.DisplayName))
+
+		var parts []models.DefinitionPart
+		attempts, err := withRetry(ctx, item.DisplayName, func() error {
+			var err error
+			parts, err = s.apiClient.GetItemDefinition(ctx, workspaceID, item.ID, "")
+			return err
+		})
+		result.Attempts = attempts
+
+		if err != nil {
+			switch {
+			case api.IsUnsupported(err):
+				logger.LogWarn(fmt.Sprintf("⚠️  Definition not supported for %s: %s", item.Type, item.DisplayName))
+				result.Outcome = models.ItemOutcomeSkipped
+				result.Error = fmt.Sprintf("definition not supported for this %s: %v", item.Type, err)
+			case api.IsAccessDenied(err):
+				logger.LogWarn(fmt.Sprintf("⚠️  Definition not available for %s: %s", item.Type, item.DisplayName))
+				result.Outcome = models.ItemOutcomeSkipped
+				result.Error = fmt.Sprintf("no permission to read the definition: %v", err)
+			default:
+				logger.LogError(fmt.Sprintf("❌ Failed to export %s definition: %s", item.Type, item.DisplayName), err)
+				result.Outcome = models.ItemOutcomeFailed
+				result.Error = err.Error()
+			}
+			results = append(results, result)
+			continue
+		}
+
+		relDir := filepath.Join("items", storage.ArtifactFileName(item.DisplayName, item.ID, ""))
+		if err := storage.SaveDefinitionParts(filepath.Join(backupDir, relDir), parts); err != nil {
+			logger.LogError(fmt.Sprintf("❌ Failed to save %s definition: %s", item.Type, item.DisplayName), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		item.DefinitionDir = filepath.ToSlash(relDir)
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,263 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
+)
+
+// backupUpstreamDataflows records the dataflows each dataset loads from
+func (s *Service) backupUpstreamDataflows(ctx context.Context, workspaceID string, datasets []models.Dataset) error {
+	response, err := s.apiClient.GetUpstreamDataflows(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string]int, len(datasets))
+	for i, dataset := range datasets {
+		index[dataset.ID] = i
+	}
+
+	value, _ := response["value"].([]interface{})
+	for _, item := range value {
+		linkMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		i, ok := index[getString(linkMap, "datasetObjectId")]
+		if !ok {
+			continue
+		}
+		datasets[i].UpstreamDataflows = append(datasets[i].UpstreamDataflows, models.UpstreamDataflow{
+			DataflowID:  getString(linkMap, "dataflowObjectId"),
+			WorkspaceID: getString(linkMap, "workspaceObjectId"),
+		})
+	}
+
+	return nil
+}
+
+// lineageEdges derives the report→dataset, dataset→dataflow and dashboard→report
+// dependencies of a workspace's items
+func lineageEdges(workspaceID string, reports []models.Report, datasets []models.Dataset, dashboards []models.Dashboard) []models.LineageEdge {
+	edges := []models.LineageEdge{}
+
+	for _, report := range reports {
+		if report.DatasetID == "" {
+			continue
+		}
+		targetWorkspaceID := report.DatasetWorkspaceID
+		if targetWorkspaceID == "" {
+			targetWorkspaceID = workspaceID
+		}
+		edges = append(edges, models.LineageEdge{
+			SourceType:        models.LineageNodeReport,
+			SourceID:          report.ID,
+			SourceWorkspaceID: workspaceID,
+			TargetType:        models.LineageNodeDataset,
+			TargetID:          report.DatasetID,
+			TargetWorkspaceID: targetWorkspaceID,
+		})
+	}
+
+	for _, dataset := range datasets {
+		for _, upstream := range dataset.UpstreamDataflows {
+			targetWorkspaceID := upstream.WorkspaceID
+			if targetWorkspaceID == "" {
+				targetWorkspaceID = workspaceID
+			}
+			edges = append(edges, models.LineageEdge{
+				SourceType:        models.LineageNodeDataset,
+				SourceID:          dataset.ID,
+				SourceWorkspaceID: workspaceID,
+				TargetType:        models.LineageNodeDataflow,
+				TargetID:          upstream.DataflowID,
+				TargetWorkspaceID: targetWorkspaceID,
+			})
+		}
+	}
+
+	for _, dashboard := range dashboards {
+		seen := make(map[string]bool)
+		for _, tile := range dashboard.Tiles {
+			if tile.ReportID == "" || seen[tile.ReportID] {
+				continue
+			}
+			seen[tile.ReportID] = true
+			edges = append(edges, models.LineageEdge{
+				SourceType:        models.LineageNodeDashboard,
+				SourceID:          dashboard.ID,
+				SourceWorkspaceID: workspaceID,
+				TargetType:        models.LineageNodeReport,
+				TargetID:          tile.ReportID,
+				TargetWorkspaceID: workspaceID,
+			})
+		}
+	}
+
+	return edges
+}
+
+// BuildLineage builds the dependency graph across the latest backup of each
+// given workspace, or of every backed-up workspace when none are given.
+// Dependencies outside the loaded backups are flagged as external.
+func (s *Service) BuildLineage(workspaceIDs []string) (*models.LineageGraph, error) {
+	if len(workspaceIDs) == 0 {
+		ids, err := s.storageService.ListWorkspaces()
+		if err != nil {
+			logger.LogError("Failed to list backed-up workspaces", err)
+			return nil, err
+		}
+		workspaceIDs = ids
+	}
+
+	graph := &models.LineageGraph{
+		Timestamp:  time.Now(),
+		Nodes:      []models.LineageNode{},
+		Edges:      []models.LineageEdge{},
+		Workspaces: []models.LineageWorkspace{},
+	}
+
+	nodes := make(map[string]int)
+	addNode := func(node models.LineageNode) {
+		key := lineageNodeKey(node.Type, node.ID)
+		if _, ok := nodes[key]; !ok {
+			nodes[key] = len(graph.Nodes)
+			graph.Nodes = append(graph.Nodes, node)
+		}
+	}
+
+	for _, workspaceID := range workspaceIDs {
+		backupPath, err := s.storageService.GetLatestBackup(workspaceID)
+		if err != nil {
+			logger.LogWarn(fmt.Sprintf("No backup for workspace %s, leaving it out of the lineage: %v", workspaceID, err))
+			continue
+		}
+		backup, err := s.storageService.LoadBackup(backupPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, report := range backup.Reports {
+			addNode(models.LineageNode{ID: report.ID, Type: models.LineageNodeReport, Name: report.Name, WorkspaceID: backup.WorkspaceID})
+		}
+		for _, dataset := range backup.Datasets {
+			addNode(models.LineageNode{ID: dataset.ID, Type: models.LineageNodeDataset, Name: dataset.Name, WorkspaceID: backup.WorkspaceID})
+		}
+		for _, dataflow := range backup.Dataflows {
+			addNode(models.LineageNode{ID: dataflow.ObjectID, Type: models.LineageNodeDataflow, Name: dataflow.Name, WorkspaceID: backup.WorkspaceID})
+		}
+		for _, dashboard := range backup.Dashboards {
+			addNode(models.LineageNode{ID: dashboard.ID, Type: models.LineageNodeDashboard, Name: dashboard.DisplayName, WorkspaceID: backup.WorkspaceID})
+		}
+
+		// Backups taken before lineage was recorded derive it from their items
+		edges := backup.Lineage
+		if edges == nil {
+			edges = lineageEdges(backup.WorkspaceID, backup.Reports, backup.Datasets, backup.Dashboards)
+		}
+		graph.Edges = append(graph.Edges, edges...)
+
+		workspace := models.LineageWorkspace{
+			WorkspaceID:          backup.WorkspaceID,
+			WorkspaceName:        backup.WorkspaceName,
+			BackupPath:           backupPath,
+			ExternalDependencies: []models.LineageEdge{},
+		}
+		for _, edge := range edges {
+			if !strings.EqualFold(edge.TargetWorkspaceID, backup.WorkspaceID) {
+				workspace.ExternalDependencies = append(workspace.ExternalDependencies, edge)
+			}
+		}
+		graph.Workspaces = append(graph.Workspaces, workspace)
+	}
+
+	// Targets no loaded backup contains are the dependencies a restore can't bring back
+	for _, edge := range graph.Edges {
+		addNode(models.LineageNode{ID: edge.TargetID, Type: edge.TargetType, WorkspaceID: edge.TargetWorkspaceID, External: true})
+	}
+
+	for _, workspace := range graph.Workspaces {
+		for _, edge := range workspace.ExternalDependencies {
+			target := graph.Nodes[nodes[lineageNodeKey(edge.TargetType, edge.TargetID)]]
+			if target.External {
+				logger.LogWarn(fmt.Sprintf("⚠️  %s: %s %s depends on %s %s in workspace %s, which is not backed up",
+					workspace.WorkspaceName, edge.SourceType, edge.SourceID, edge.TargetType, edge.TargetID, edge.TargetWorkspaceID))
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// LineageDOT renders a lineage graph in Graphviz DOT format, grouping nodes by
+// workspace. External nodes are dashed and cross-workspace edges are red.
+func LineageDOT(graph *models.LineageGraph) string {
+	workspaceNames := make(map[string]string)
+	for _, workspace := range graph.Workspaces {
+		workspaceNames[workspace.WorkspaceID] = workspace.WorkspaceName
+	}
+
+	byWorkspace := make(map[string][]models.LineageNode)
+	var workspaceIDs []string
+	for _, node := range graph.Nodes {
+		if _, ok := byWorkspace[node.WorkspaceID]; !ok {
+			workspaceIDs = append(workspaceIDs, node.WorkspaceID)
+		}
+		byWorkspace[node.WorkspaceID] = append(byWorkspace[node.WorkspaceID], node)
+	}
+	sort.Strings(workspaceIDs)
+
+	var b strings.Builder
+	b.WriteString("digraph lineage {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontsize=10];\n")
+
+	for i, workspaceID := range workspaceIDs {
+		label := workspaceID
+		if name := workspaceNames[workspaceID]; name != "" {
+			label = name
+		}
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(label))
+		for _, node := range byWorkspace[workspaceID] {
+			name := node.Name
+			if name == "" {
+				name = node.ID
+			}
+			style := ""
+			if node.External {
+				style = ", style=dashed"
+			}
+			fmt.Fprintf(&b, "    %s [label=%s%s];\n",
+				dotQuote(lineageNodeKey(node.Type, node.ID)), dotQuote(node.Type+"\n"+name), style)
+		}
+		b.WriteString("  }\n")
+	}
+
+	for _, edge := range graph.Edges {
+		style := ""
+		if !strings.EqualFold(edge.SourceWorkspaceID, edge.TargetWorkspaceID) {
+			style = " [color=red]"
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n",
+			dotQuote(lineageNodeKey(edge.SourceType, edge.SourceID)), dotQuote(lineageNodeKey(edge.TargetType, edge.TargetID)), style)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func lineageNodeKey(nodeType, id string) string {
+	return nodeType + ":" + strings.ToLower(id)
+}
+
+func dotQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
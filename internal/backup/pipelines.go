@@ -0,0 +1,140 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
+)
+
+// BackupPipelines saves a tenant-level inventory of the deployment pipelines the
+// principal has access to, with their stages, assigned workspaces, users and
+// operations history
+func (s *Service) BackupPipelines(ctx context.Context) (*models.PipelineInventory, string, error) {
+	logger.LogInfo("Starting deployment pipeline backup")
+
+	inventory := &models.PipelineInventory{
+		Timestamp: time.Now(),
+		Items:     []models.ItemResult{},
+		Pipelines: []models.Pipeline{},
+	}
+
+	response, err := s.apiClient.GetPipelines(ctx)
+	if err != nil {
+		logger.LogError("Failed to list deployment pipelines", err)
+		inventory.Items = append(inventory.Items, componentFailure("pipelines", err))
+	} else {
+		value, _ := response["value"].([]interface{})
+		for _, item := range value {
+			pipelineMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			pipeline := models.Pipeline{
+				ID:          getString(pipelineMap, "id"),
+				DisplayName: getString(pipelineMap, "displayName"),
+				Description: getString(pipelineMap, "description"),
+				Stages:      []models.PipelineStage{},
+				Operations:  []models.PipelineOperation{},
+			}
+			inventory.Items = append(inventory.Items, s.backupPipelineDetails(ctx, &pipeline))
+			inventory.Pipelines = append(inventory.Pipelines, pipeline)
+		}
+		logger.LogInfo(fmt.Sprintf("Successfully backed up %d deployment pipelines", len(inventory.Pipelines)))
+	}
+
+	inventory.Status = summarizeStatus(inventory.Items)
+
+	path, err := s.storageService.SavePipelineInventory(inventory)
+	if err != nil {
+		logger.LogError("Failed to save pipeline inventory", err)
+		return nil, "", err
+	}
+
+	return inventory, path, nil
+}
+
+// backupPipelineDetails fills in the stages, users and operations history of a
+// pipeline. The stages and users are required; a missing operations history only
+// logs a warning.
+func (s *Service) backupPipelineDetails(ctx context.Context, pipeline *models.Pipeline) models.ItemResult {
+	result := models.ItemResult{
+		ItemType: models.ItemTypePipeline,
+		ItemID:   pipeline.ID,
+		Name:     pipeline.DisplayName,
+		Attempts: 1,
+	}
+
+	response, err := s.apiClient.GetPipelineStages(ctx, pipeline.ID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to get stages of pipeline: %s", pipeline.DisplayName), err)
+		result.Outcome = models.ItemOutcomeFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	value, _ := response["value"].([]interface{})
+	for _, item := range value {
+		stageMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pipeline.Stages = append(pipeline.Stages, models.PipelineStage{
+			Order:         getInt(stageMap, "order"),
+			WorkspaceID:   getString(stageMap, "workspaceId"),
+			WorkspaceName: getString(stageMap, "workspaceName"),
+		})
+	}
+
+	response, err = s.apiClient.GetPipelineUsers(ctx, pipeline.ID)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to get users of pipeline: %s", pipeline.DisplayName), err)
+		result.Outcome = models.ItemOutcomeFailed
+		result.Error = fmt.Sprintf("users: %v", err)
+		return result
+	}
+
+	value, _ = response["value"].([]interface{})
+	for _, item := range value {
+		userMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pipeline.Users = append(pipeline.Users, models.PipelineUser{
+			Identifier:    getString(userMap, "identifier"),
+			PrincipalType: getString(userMap, "principalType"),
+			AccessRight:   getString(userMap, "accessRight"),
+		})
+	}
+
+	response, err = s.apiClient.GetPipelineOperations(ctx, pipeline.ID)
+	if err != nil {
+		logger.LogWarn(fmt.Sprintf("Failed to get operations of pipeline %s: %v", pipeline.DisplayName, err))
+	} else {
+		value, _ := response["value"].([]interface{})
+		for _, item := range value {
+			operationMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			note, _ := operationMap["note"].(map[string]interface{})
+			pipeline.Operations = append(pipeline.Operations, models.PipelineOperation{
+				ID:                 getString(operationMap, "id"),
+				Type:               getString(operationMap, "type"),
+				Status:             getString(operationMap, "status"),
+				LastUpdatedTime:    getString(operationMap, "lastUpdatedTime"),
+				ExecutionStartTime: getString(operationMap, "executionStartTime"),
+				ExecutionEndTime:   getString(operationMap, "executionEndTime"),
+				SourceStageOrder:   getInt(operationMap, "sourceStageOrder"),
+				TargetStageOrder:   getInt(operationMap, "targetStageOrder"),
+				Note:               getString(note, "content"),
+			})
+		}
+	}
+
+	result.Outcome = models.ItemOutcomeExported
+	return result
+}
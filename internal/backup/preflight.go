@@ -0,0 +1,128 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/veeam/powerbi-backup-go/internal/api"
+	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
+)
+
+// largeModelStorageMode is the target storage mode of datasets using the large
+// semantic model format, which the service may refuse to download as PBIX
+const largeModelStorageMode = "PremiumFiles"
+
+// Preflight checks, without exporting anything, what a backup of each workspace
+// would include: workspace access, capacity type, item counts and whether each
+// report can be exported
+func (s *Service) Preflight(ctx context.Context, workspaces []models.WorkspaceRef) *models.PreflightReport {
+	report := &models.PreflightReport{
+		Timestamp:  time.Now(),
+		Workspaces: make([]models.WorkspacePreflight, 0, len(workspaces)),
+	}
+
+	for i, ws := range workspaces {
+		logger.LogInfo(fmt.Sprintf("[%d/%d] Preflight for workspace: %s (%s)", i+1, len(workspaces), ws.Name, ws.ID))
+		report.Workspaces = append(report.Workspaces, s.preflightWorkspace(ctx, ws))
+	}
+
+	return report
+}
+
+func (s *Service) preflightWorkspace(ctx context.Context, ws models.WorkspaceRef) models.WorkspacePreflight {
+	result := models.WorkspacePreflight{
+		WorkspaceID:   ws.ID,
+		WorkspaceName: ws.Name,
+		CapacityID:    ws.CapacityID,
+	}
+
+	workspaceData, err := s.apiClient.GetWorkspaceSettings(ctx, ws.ID)
+	if err != nil {
+		result.Error = accessError(err)
+		return result
+	}
+	result.Accessible = true
+	if result.WorkspaceName == "" {
+		result.WorkspaceName = getString(workspaceData, "name")
+	}
+	if capacityID := getString(workspaceData, "capacityId"); capacityID != "" {
+		result.CapacityID = capacityID
+	}
+	result.CapacityType = "Shared"
+	if getBool(workspaceData, "isOnDedicatedCapacity") {
+		result.CapacityType = "Dedicated"
+	}
+
+	reports, err := s.backupReports(ctx, ws.ID)
+	if err != nil {
+		result.Error = fmt.Sprintf("cannot list reports: %s", accessError(err))
+		return result
+	}
+	datasets, err := s.backupDatasets(ctx, ws.ID)
+	if err != nil {
+		result.Error = fmt.Sprintf("cannot list datasets: %s", accessError(err))
+		return result
+	}
+	result.Reports = len(reports)
+	result.Datasets = len(datasets)
+
+	if dataflows, err := s.apiClient.GetDataflows(ctx, ws.ID); err == nil {
+		value, _ := dataflows["value"].([]interface{})
+		result.Dataflows = len(value)
+	}
+	if dashboards, err := s.apiClient.GetDashboards(ctx, ws.ID); err == nil {
+		value, _ := dashboards["value"].([]interface{})
+		result.Dashboards = len(value)
+	}
+
+	datasetsByID := make(map[string]models.Dataset, len(datasets))
+	for _, dataset := range datasets {
+		datasetsByID[dataset.ID] = dataset
+	}
+
+	result.Items = make([]models.PreflightItem, 0, len(reports))
+	for _, report := range reports {
+		result.Items = append(result.Items, reportExportability(report, datasetsByID))
+	}
+
+	return result
+}
+
+// reportExportability derives from report and dataset metadata whether the
+// report file export is expected to work
+func reportExportability(report models.Report, datasets map[string]models.Dataset) models.PreflightItem {
+	item := models.PreflightItem{
+		ItemType:   models.ItemTypeReportPBIX,
+		ItemID:     report.ID,
+		Name:       report.Name,
+		Exportable: true,
+	}
+
+	if report.ReportType == models.ReportTypePaginated {
+		item.ItemType = models.ItemTypeReportRDL
+		item.Reason = "exported as RDL"
+		return item
+	}
+
+	dataset, ok := datasets[report.DatasetID]
+	switch {
+	case !ok:
+		item.Reason = "dataset is in another workspace; exported without its model"
+	case dataset.AddRowsAPIEnabled:
+		item.Exportable = false
+		item.Reason = "push datasets cannot be downloaded"
+	case dataset.TargetStorageMode == largeModelStorageMode:
+		item.Reason = "large semantic model format; download may be blocked"
+	}
+
+	return item
+}
+
+func accessError(err error) string {
+	if api.IsAccessDenied(err) {
+		return "access denied - add the service principal to the workspace"
+	}
+	return err.Error()
+}
@@ -0,0 +1,140 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
+	"github.com/veeam/powerbi-backup-go/internal/storage"
+)
+
+// runIDFormat names runs started without an explicit run ID
+const runIDFormat = "20060102-150405"
+
+// NewRun creates the checkpoint for a new tenant-wide backup run over the
+// selected workspaces. An empty runID is generated from the current time.
+func NewRun(runID string, workspaces []models.WorkspaceRef, opts Options) *models.BackupRun {
+	if runID == "" {
+		runID = time.Now().Format(runIDFormat)
+	}
+
+	run := &models.BackupRun{
+		RunID:      runID,
+		StartedAt:  time.Now(),
+		Status:     models.RunStatusPending,
+		Components: opts.Components,
+		Workspaces: make([]models.WorkspaceRun, 0, len(workspaces)),
+	}
+	for _, ws := range workspaces {
+		run.Workspaces = append(run.Workspaces, models.WorkspaceRun{
+			WorkspaceID:   ws.ID,
+			WorkspaceName: ws.Name,
+			Status:        models.RunStatusPending,
+		})
+	}
+	return run
+}
+
+// RunBackup backs up the workspaces of a run, saving the checkpoint after every
+// change. Completed workspaces are skipped, so rerunning an interrupted run
+// resumes where it stopped; a workspace that was in progress continues in its
+// staging directory and reuses the report files the checkpoint records as exported
+// there. Metadata is always read again.
+func (s *Service) RunBackup(ctx context.Context, run *models.BackupRun) error {
+	run.Status = models.RunStatusInProgress
+	if err := s.storageService.SaveRun(run); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to save checkpoint for run %s", run.RunID), err)
+		return err
+	}
+
+	logger.LogInfo(fmt.Sprintf("🏃 Backup run %s: %d workspaces", run.RunID, len(run.Workspaces)))
+
+	// Read the tenant's apps once for the run rather than once per workspace
+	stopAppCache := s.startAppCache()
+	defer stopAppCache()
+
+	successCount := 0
+	failCount := 0
+
+	for i := range run.Workspaces {
+		ws := &run.Workspaces[i]
+		if ws.Status == models.RunStatusCompleted {
+			logger.LogInfo(fmt.Sprintf("[%d/%d] Already backed up: %s (%s)", i+1, len(run.Workspaces), ws.WorkspaceName, ws.WorkspaceID))
+			successCount++
+			continue
+		}
+
+		opts := Options{Components: run.Components}
+		if ws.Status == models.RunStatusInProgress && ws.StagingTime != nil {
+			// The run may have stopped after the backup was saved but before the checkpoint was
+			backupDir := s.storageService.BackupDir(ws.WorkspaceID, *ws.StagingTime)
+			if storage.IsCompleteBackupDir(backupDir) {
+				logger.LogInfo(fmt.Sprintf("[%d/%d] Already backed up: %s (%s)", i+1, len(run.Workspaces), ws.WorkspaceName, ws.WorkspaceID))
+				ws.Status = models.RunStatusCompleted
+				ws.BackupPath = backupDir
+				successCount++
+				continue
+			}
+			opts.StagingTime = *ws.StagingTime
+			opts.ExportedFiles = ws.ExportedFiles
+		} else {
+			stagingTime := time.Now()
+			ws.StagingTime = &stagingTime
+			ws.ExportedFiles = nil
+			opts.StagingTime = stagingTime
+		}
+		opts.OnExported = func(itemID string, size int64) {
+			if ws.ExportedFiles == nil {
+				ws.ExportedFiles = make(map[string]int64)
+			}
+			ws.ExportedFiles[itemID] = size
+			if err := s.storageService.SaveRun(run); err != nil {
+				logger.LogWarn(fmt.Sprintf("Failed to save checkpoint for run %s: %v", run.RunID, err))
+			}
+		}
+
+		ws.Status = models.RunStatusInProgress
+		ws.Error = ""
+		if err := s.storageService.SaveRun(run); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to save checkpoint for run %s", run.RunID), err)
+			return err
+		}
+
+		logger.LogInfo(fmt.Sprintf("[%d/%d] Backing up workspace: %s (%s)", i+1, len(run.Workspaces), ws.WorkspaceName, ws.WorkspaceID))
+
+		backup, err := s.BackupWorkspace(ctx, ws.WorkspaceID, opts)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to backup workspace: %s", ws.WorkspaceName), err)
+			// BackupWorkspace discards the staging directory on failure
+			ws.Status = models.RunStatusFailed
+			ws.StagingTime = nil
+			ws.ExportedFiles = nil
+			ws.Error = err.Error()
+			failCount++
+		} else {
+			ws.Status = models.RunStatusCompleted
+			ws.BackupPath = s.storageService.BackupDir(ws.WorkspaceID, backup.Timestamp)
+			ws.ExportedFiles = nil
+			successCount++
+		}
+
+		if err := s.storageService.SaveRun(run); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to save checkpoint for run %s", run.RunID), err)
+			return err
+		}
+	}
+
+	run.Status = models.RunStatusCompleted
+	if failCount > 0 {
+		run.Status = models.RunStatusFailed
+	}
+	if err := s.storageService.SaveRun(run); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to save checkpoint for run %s", run.RunID), err)
+		return err
+	}
+
+	logger.LogInfo(fmt.Sprintf("✅ Backup run %s completed: %d succeeded, %d failed", run.RunID, successCount, failCount))
+	return nil
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/veeam/powerbi-backup-go/internal/api"
@@ -13,10 +16,31 @@ import (
 	"github.com/veeam/powerbi-backup-go/internal/storage"
 )
 
+const (
+	// maxExportAttempts is the number of times an export is tried before giving up
+	maxExportAttempts = 3
+	// exportRetryDelay is the base delay between export attempts
+	exportRetryDelay = 5 * time.Second
+	// refreshHistoryDepth is the number of refresh history entries kept per dataset
+	refreshHistoryDepth = 10
+	// modelDefinitionFormat is the format semantic model definitions are saved in
+	modelDefinitionFormat = "TMDL"
+)
+
 // Service orchestrates the backup of all Power BI components
 type Service struct {
 	apiClient      *api.Client
 	storageService *storage.StorageService
+
+	// Tenant app listing shared by the workspaces of the current run
+	appsMu   sync.Mutex
+	appCache *appCache
+}
+
+// appCache holds the tenant apps and their reports for the duration of a run
+type appCache struct {
+	apps    []models.App
+	reports map[string][]models.AppReport
 }
 
 // NewService creates a new backup service
@@ -28,13 +52,24 @@ func NewService(apiClient *api.Client, storageService *storage.StorageService) *
 }
 
 // BackupWorkspace performs a complete backup of a workspace
-func (s *Service) BackupWorkspace(ctx context.Context, workspaceID string) (*models.CompleteBackup, error) {
+func (s *Service) BackupWorkspace(ctx context.Context, workspaceID string, opts Options) (*models.CompleteBackup, error) {
 	logger.LogInfo(fmt.Sprintf("Starting backup for workspace: %s", workspaceID))
 
-	// Create backup directory first - use consistent timestamp
+	selected, err := ResolveComponents(opts.Components, false)
+	if err != nil {
+		return nil, err
+	}
+	components := newComponentSet(selected)
+	logger.LogInfo(fmt.Sprintf("Backup components: %s", strings.Join(selected, ", ")))
+
+	// Create staging directory first - use consistent timestamp.
+	// SaveBackup promotes it to the final timestamp directory once the backup is written.
 	backupTime := time.Now()
-	timestamp := backupTime.Format("2006-01-02_15-04-05")
-	backupDir := filepath.Join(s.storageService.GetBackupPath(), workspaceID, timestamp)
+	if !opts.StagingTime.IsZero() {
+		backupTime = opts.StagingTime
+		logger.LogInfo(fmt.Sprintf("Resuming backup in: %s", s.storageService.StagingDir(workspaceID, backupTime)))
+	}
+	backupDir := s.storageService.StagingDir(workspaceID, backupTime)
 
 	if err := os.MkdirAll(backupDir, 0755); err != nil {
 		logger.LogError(fmt.Sprintf("Failed to create backup directory: %s", backupDir), err)
@@ -45,99 +80,219 @@ func (s *Service) BackupWorkspace(ctx context.Context, workspaceID string) (*mod
 	workspaceData, err := s.apiClient.GetWorkspaceSettings(ctx, workspaceID)
 	if err != nil {
 		logger.LogError("Failed to get workspace settings", err)
+		s.storageService.DiscardStaging(workspaceID, backupTime)
 		return nil, err
 	}
 
 	workspaceName, _ := workspaceData["name"].(string)
 
 	backup := &models.CompleteBackup{
-		Timestamp:     backupTime,
-		WorkspaceID:   workspaceID,
-		WorkspaceName: workspaceName,
-		WorkspaceSettings: models.WorkspaceSettings{
-			ID:   workspaceID,
-			Name: workspaceName,
-		},
-	}
-
-	// Backup reports
-	logger.LogInfo("Backing up reports...")
-	reports, err := s.backupReports(ctx, workspaceID)
-	if err != nil {
-		logger.LogError("Failed to backup reports", err)
-	} else {
-		backup.Reports = reports
-		logger.LogInfo(fmt.Sprintf("Successfully backed up %d reports", len(reports)))
+		Timestamp:         backupTime,
+		WorkspaceID:       workspaceID,
+		WorkspaceName:     workspaceName,
+		Components:        selected,
+		Items:             []models.ItemResult{},
+		Artifacts:         []models.ArtifactFile{},
+		Users:             []models.WorkspaceUser{},
+		WorkspaceSettings: parseWorkspaceSettings(workspaceID, workspaceData),
 	}
 
-	// Backup datasets
-	logger.LogInfo("Backing up datasets...")
-	datasets, err := s.backupDatasets(ctx, workspaceID)
-	if err != nil {
-		logger.LogError("Failed to backup datasets", err)
-	} else {
-		backup.Datasets = datasets
-		logger.LogInfo(fmt.Sprintf("Successfully backed up %d datasets", len(datasets)))
+	// Item lists are fetched when their component or a component depending on them is selected
+	var reports []models.Report
+	if components.any(ComponentReports, ComponentPBIX, ComponentPermissions, ComponentSubscriptions, ComponentApps) {
+		logger.LogInfo("Backing up reports...")
+		reports, err = s.backupReports(ctx, workspaceID)
+		if err != nil {
+			logger.LogError("Failed to backup reports", err)
+			backup.Items = append(backup.Items, componentFailure("reports", err))
+		} else {
+			backup.Reports = reports
+			logger.LogInfo(fmt.Sprintf("Successfully backed up %d reports", len(reports)))
+
+			// Capture page structure so the backup documents each report even without its PBIX
+			if components[ComponentReports] {
+				logger.LogInfo("Backing up report pages...")
+				backup.Items = append(backup.Items, s.backupReportPages(ctx, workspaceID, reports)...)
+			}
+		}
 	}
 
-	// Backup dataflows
-	logger.LogInfo("Backing up dataflows...")
-	dataflows, err := s.backupDataflows(ctx, workspaceID)
-	if err != nil {
-		logger.LogError("Failed to backup dataflows", err)
-	} else {
-		backup.Dataflows = dataflows
-		logger.LogInfo(fmt.Sprintf("Successfully backed up %d dataflows", len(dataflows)))
+	var datasets []models.Dataset
+	if components.any(ComponentDatasets, ComponentPBIX, ComponentPermissions, ComponentSchedules, ComponentDefinitions) {
+		logger.LogInfo("Backing up datasets...")
+		datasets, err = s.backupDatasets(ctx, workspaceID)
+		if err != nil {
+			logger.LogError("Failed to backup datasets", err)
+			backup.Items = append(backup.Items, componentFailure("datasets", err))
+		} else {
+			backup.Datasets = datasets
+			logger.LogInfo(fmt.Sprintf("Successfully backed up %d datasets", len(datasets)))
+
+			if components[ComponentDatasets] {
+				logger.LogInfo("Backing up dataset parameters and datasources...")
+				backup.Items = append(backup.Items, s.backupDatasetConnections(ctx, workspaceID, datasets)...)
+
+				// Backup refresh history so the backup shows whether each model was healthy
+				logger.LogInfo("Backing up refresh history...")
+				backup.Items = append(backup.Items, s.backupRefreshHistory(ctx, workspaceID, datasets)...)
+
+				logger.LogInfo("Backing up upstream dataflows...")
+				if err := s.backupUpstreamDataflows(ctx, workspaceID, datasets); err != nil {
+					logger.LogError("Failed to backup upstream dataflows", err)
+					backup.Items = append(backup.Items, componentFailure("upstream dataflows", err))
+				}
+			}
+
+			// Keep the model definition so datasets can be recreated when no PBIX is available
+			if components[ComponentDefinitions] {
+				logger.LogInfo("Exporting semantic model definitions...")
+				backup.Items = append(backup.Items, s.backupModelDefinitions(ctx, workspaceID, datasets, backupDir)...)
+			}
+		}
 	}
 
-	// Backup dashboards
-	logger.LogInfo("Backing up dashboards...")
-	dashboards, err := s.backupDashboards(ctx, workspaceID)
-	if err != nil {
-		logger.LogError("Failed to backup dashboards", err)
-	} else {
-		backup.Dashboards = dashboards
-		logger.LogInfo(fmt.Sprintf("Successfully backed up %d dashboards", len(dashboards)))
+	if components.any(ComponentDataflows, ComponentDefinitions) {
+		logger.LogInfo("Backing up dataflows...")
+		dataflows, err := s.backupDataflows(ctx, workspaceID)
+		if err != nil {
+			logger.LogError("Failed to backup dataflows", err)
+			backup.Items = append(backup.Items, componentFailure("dataflows", err))
+		} else {
+			backup.Dataflows = dataflows
+			logger.LogInfo(fmt.Sprintf("Successfully backed up %d dataflows", len(dataflows)))
+
+			if components[ComponentDefinitions] {
+				logger.LogInfo("Exporting dataflow definitions...")
+				dataflowResults, err := s.backupDataflowDefinitions(ctx, workspaceID, dataflows, backupDir)
+				if err != nil {
+					logger.LogError("Failed to export dataflow definitions", err)
+					backup.Items = append(backup.Items, componentFailure("dataflow definitions", err))
+				} else {
+					backup.Items = append(backup.Items, dataflowResults...)
+				}
+			}
+		}
 	}
 
-	// Backup apps
-	logger.LogInfo("Backing up apps...")
-	apps, err := s.backupApps(ctx, workspaceID)
-	if err != nil {
-		logger.LogWarn("Failed to backup apps (optional)")
-	} else {
-		backup.Apps = apps
-		logger.LogInfo(fmt.Sprintf("Successfully backed up %d apps", len(apps)))
+	if components.any(ComponentItems, ComponentDefinitions) {
+		logger.LogInfo("Backing up Fabric items...")
+		fabricItems, err := s.backupFabricItems(ctx, workspaceID)
+		if err != nil {
+			// Workspaces without Fabric access still back up their Power BI content
+			if api.IsAccessDenied(err) {
+				logger.LogWarn(fmt.Sprintf("Fabric items not accessible, skipping: %v", err))
+			} else {
+				logger.LogError("Failed to backup Fabric items", err)
+				backup.Items = append(backup.Items, componentFailure("items", err))
+			}
+		} else {
+			backup.FabricItems = fabricItems
+			logger.LogInfo(fmt.Sprintf("Successfully backed up %d Fabric items", len(fabricItems)))
+
+			if components[ComponentDefinitions] {
+				logger.LogInfo("Exporting Fabric item definitions...")
+				backup.Items = append(backup.Items, s.backupFabricItemDefinitions(ctx, workspaceID, fabricItems, backupDir)...)
+			}
+		}
 	}
 
-	// Backup refresh schedules
-	logger.LogInfo("Backing up refresh schedules...")
-	schedules, err := s.backupRefreshSchedules(ctx, workspaceID, datasets)
-	if err != nil {
-		logger.LogError("Failed to backup refresh schedules", err)
-	} else {
+	if components.any(ComponentDashboards, ComponentSubscriptions) {
+		logger.LogInfo("Backing up dashboards...")
+		dashboards, err := s.backupDashboards(ctx, workspaceID)
+		if err != nil {
+			logger.LogError("Failed to backup dashboards", err)
+			backup.Items = append(backup.Items, componentFailure("dashboards", err))
+		} else {
+			backup.Dashboards = dashboards
+			logger.LogInfo(fmt.Sprintf("Successfully backed up %d dashboards", len(dashboards)))
+
+			if components[ComponentDashboards] {
+				logger.LogInfo("Backing up dashboard tiles...")
+				backup.Items = append(backup.Items, s.backupDashboardTiles(ctx, workspaceID, dashboards)...)
+			}
+		}
+	}
+
+	if components[ComponentApps] {
+		logger.LogInfo("Backing up apps...")
+		apps, appResults, err := s.backupApps(ctx, workspaceID, reports)
+		if err != nil {
+			logger.LogError("Failed to backup apps", err)
+			backup.Items = append(backup.Items, componentFailure("apps", err))
+		} else {
+			backup.Apps = apps
+			backup.Items = append(backup.Items, appResults...)
+			logger.LogInfo(fmt.Sprintf("Successfully backed up %d apps", len(apps)))
+		}
+	}
+
+	if components[ComponentUsers] {
+		logger.LogInfo("Backing up workspace users...")
+		users, err := s.backupWorkspaceUsers(ctx, workspaceID)
+		if err != nil {
+			logger.LogError("Failed to backup workspace users", err)
+			backup.Items = append(backup.Items, componentFailure("users", err))
+		} else {
+			backup.Users = users
+			logger.LogInfo(fmt.Sprintf("Successfully backed up %d workspace users", len(users)))
+		}
+	}
+
+	if components[ComponentPermissions] {
+		logger.LogInfo("Backing up dataset and report permissions...")
+		backup.Items = append(backup.Items, s.backupItemPermissions(ctx, workspaceID, reports, datasets)...)
+	}
+
+	if components[ComponentSubscriptions] {
+		logger.LogInfo("Backing up subscriptions...")
+		subscriptions, subscriptionResults := s.backupSubscriptions(ctx, backup.Reports, backup.Dashboards)
+		backup.Subscriptions = subscriptions
+		backup.Items = append(backup.Items, subscriptionResults...)
+		logger.LogInfo(fmt.Sprintf("Successfully backed up %d subscriptions", len(subscriptions)))
+	}
+
+	if components[ComponentSchedules] {
+		logger.LogInfo("Backing up refresh schedules...")
+		schedules, scheduleResults := s.backupRefreshSchedules(ctx, workspaceID, datasets)
 		backup.RefreshSchedules = schedules
+		backup.Items = append(backup.Items, scheduleResults...)
 		logger.LogInfo(fmt.Sprintf("Successfully backed up %d refresh schedules", len(schedules)))
 	}
 
-	// Export PBIX files for reports
-	logger.LogInfo("Exporting reports as PBIX files...")
-	pbixStatus, err := s.backupReportsPBIX(ctx, workspaceID, reports, backupDir)
-	if err != nil {
-		logger.LogWarn(fmt.Sprintf("PBIX export failed: %v", err))
-	} else {
-		logger.LogInfo(fmt.Sprintf("PBIX export status: %d succeeded, %d failed", pbixStatus["succeeded"], pbixStatus["failed"]))
+	if components[ComponentPBIX] {
+		logger.LogInfo("Exporting reports as PBIX files...")
+		pbixResults, artifacts, err := s.backupReportsPBIX(ctx, workspaceID, reports, datasets, backupDir, opts)
+		if err != nil {
+			logger.LogWarn(fmt.Sprintf("PBIX export failed: %v", err))
+			backup.Items = append(backup.Items, componentFailure("pbix", err))
+		} else {
+			backup.Items = append(backup.Items, pbixResults...)
+			backup.Artifacts = append(backup.Artifacts, artifacts...)
+			logger.LogInfo(fmt.Sprintf("Report export status: %d succeeded, %d failed",
+				countOutcome(pbixResults, models.ItemOutcomeExported), countOutcome(pbixResults, models.ItemOutcomeFailed)))
+		}
 	}
 
+	backup.Lineage = lineageEdges(workspaceID, backup.Reports, backup.Datasets, backup.Dashboards)
+	backup.Status = summarizeStatus(backup.Items)
+
 	// Save backup to storage
 	logger.LogInfo("Saving backup to storage...")
 	backupPath, err := s.storageService.SaveBackup(backup)
 	if err != nil {
 		logger.LogError("Failed to save backup", err)
+		s.storageService.DiscardStaging(workspaceID, backupTime)
 		return nil, err
 	}
 
-	logger.LogInfo(fmt.Sprintf("✅ Backup completed successfully: %s", backupPath))
+	switch backup.Status {
+	case models.BackupStatusComplete:
+		logger.LogInfo(fmt.Sprintf("✅ Backup completed successfully: %s", backupPath))
+	case models.BackupStatusPartial:
+		logger.LogWarn(fmt.Sprintf("⚠️  Backup completed with %d failed items: %s", countOutcome(backup.Items, models.ItemOutcomeFailed), backupPath))
+	default:
+		logger.LogError(fmt.Sprintf("❌ Backup failed, nothing could be backed up: %s", backupPath), nil)
+	}
 	return backup, nil
 }
 
@@ -160,11 +315,16 @@ func (s *Service) backupReports(ctx context.Context, workspaceID string) ([]mode
 		}
 
 		report := models.Report{
-			ID:        getString(reportMap, "id"),
-			Name:      getString(reportMap, "name"),
-			DatasetID: getString(reportMap, "datasetId"),
-			EmbedURL:  getString(reportMap, "embedUrl"),
-			WebURL:    getString(reportMap, "webUrl"),
+			ID:         getString(reportMap, "id"),
+			Name:       getString(reportMap, "name"),
+			ReportType: getString(reportMap, "reportType"),
+			DatasetID:  getString(reportMap, "datasetId"),
+			EmbedURL:   getString(reportMap, "embedUrl"),
+			WebURL:     getString(reportMap, "webUrl"),
+		}
+		// The API returns the dataset's workspace for every report; only keep it when it differs
+		if datasetWorkspaceID := getString(reportMap, "datasetWorkspaceId"); !strings.EqualFold(datasetWorkspaceID, workspaceID) {
+			report.DatasetWorkspaceID = datasetWorkspaceID
 		}
 		reports = append(reports, report)
 	}
@@ -172,6 +332,63 @@ func (s *Service) backupReports(ctx context.Context, workspaceID string) ([]mode
 	return reports, nil
 }
 
+// backupReportPages fills in the pages of each report
+func (s *Service) backupReportPages(ctx context.Context, workspaceID string, reports []models.Report) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(reports))
+
+	for i := range reports {
+		report := &reports[i]
+		result := models.ItemResult{
+			ItemType: models.ItemTypeReportPages,
+			ItemID:   report.ID,
+			Name:     report.Name,
+			Attempts: 1,
+		}
+
+		// Paginated reports have no pages endpoint, their layout lives in the RDL
+		if report.ReportType == models.ReportTypePaginated {
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = "paginated report"
+			result.Attempts = 0
+			results = append(results, result)
+			continue
+		}
+
+		response, err := s.apiClient.GetReportPages(ctx, workspaceID, report.ID)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to get pages for report: %s", report.Name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		value, _ := response["value"].([]interface{})
+		pages := make([]models.ReportPage, 0, len(value))
+		for _, item := range value {
+			pageMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			pages = append(pages, models.ReportPage{
+				Name:        getString(pageMap, "name"),
+				DisplayName: getString(pageMap, "displayName"),
+				Order:       getInt(pageMap, "order"),
+			})
+		}
+
+		sort.SliceStable(pages, func(a, b int) bool { return pages[a].Order < pages[b].Order })
+		report.Pages = pages
+
+		logger.LogDebug(fmt.Sprintf("Report %s has %d pages", report.Name, len(pages)))
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
+	}
+
+	return results
+}
+
 func (s *Service) backupDatasets(ctx context.Context, workspaceID string) ([]models.Dataset, error) {
 	response, err := s.apiClient.GetDatasets(ctx, workspaceID)
 	if err != nil {
@@ -191,11 +408,27 @@ func (s *Service) backupDatasets(ctx context.Context, workspaceID string) ([]mod
 		}
 
 		dataset := models.Dataset{
-			ID:                  getString(datasetMap, "id"),
-			Name:                getString(datasetMap, "name"),
-			IsRefreshable:       getBool(datasetMap, "isRefreshable"),
-			IsEffectiveIdentityRequired: getBool(datasetMap, "isEffectiveIdentityRequired"),
+			ID:                               getString(datasetMap, "id"),
+			Name:                             getString(datasetMap, "name"),
+			Description:                      getString(datasetMap, "description"),
+			WebURL:                           getString(datasetMap, "webUrl"),
+			CreatedDate:                      getString(datasetMap, "createdDate"),
+			TargetStorageMode:                getString(datasetMap, "targetStorageMode"),
+			IsRefreshable:                    getBool(datasetMap, "isRefreshable"),
+			IsEffectiveIdentityRequired:      getBool(datasetMap, "isEffectiveIdentityRequired"),
 			IsEffectiveIdentityRolesRequired: getBool(datasetMap, "isEffectiveIdentityRolesRequired"),
+			IsOnPremGatewayRequired:          getBool(datasetMap, "isOnPremGatewayRequired"),
+			AddRowsAPIEnabled:                getBool(datasetMap, "addRowsAPIEnabled"),
+		}
+		if configuredBy := getString(datasetMap, "configuredBy"); configuredBy != "" {
+			dataset.ConfigRefreshType = &configuredBy
+		}
+		if endorsement, ok := datasetMap["endorsementDetails"].(map[string]interface{}); ok {
+			dataset.Endorsement = getString(endorsement, "endorsement")
+			dataset.CertifiedBy = getString(endorsement, "certifiedBy")
+		}
+		if label, ok := datasetMap["sensitivityLabel"].(map[string]interface{}); ok {
+			dataset.SensitivityLabelID = getString(label, "labelId")
 		}
 		datasets = append(datasets, dataset)
 	}
@@ -203,6 +436,80 @@ func (s *Service) backupDatasets(ctx context.Context, workspaceID string) ([]mod
 	return datasets, nil
 }
 
+// backupDatasetConnections fills in the parameters and datasources of each dataset
+func (s *Service) backupDatasetConnections(ctx context.Context, workspaceID string, datasets []models.Dataset) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(datasets))
+
+	for i := range datasets {
+		dataset := &datasets[i]
+		result := models.ItemResult{
+			ItemType: models.ItemTypeDatasetConnections,
+			ItemID:   dataset.ID,
+			Name:     dataset.Name,
+			Attempts: 1,
+			Outcome:  models.ItemOutcomeFailed,
+		}
+
+		paramsResp, err := s.apiClient.GetDatasetParameters(ctx, workspaceID, dataset.ID)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to get parameters for dataset: %s", dataset.Name), err)
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		value, _ := paramsResp["value"].([]interface{})
+		parameters := make([]models.DatasetParameter, 0, len(value))
+		for _, item := range value {
+			paramMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			parameters = append(parameters, models.DatasetParameter{
+				Name:         getString(paramMap, "name"),
+				Type:         getString(paramMap, "type"),
+				IsRequired:   getBool(paramMap, "isRequired"),
+				CurrentValue: getString(paramMap, "currentValue"),
+			})
+		}
+
+		datasourcesResp, err := s.apiClient.GetDatasources(ctx, workspaceID, dataset.ID)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to get datasources for dataset: %s", dataset.Name), err)
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		value, _ = datasourcesResp["value"].([]interface{})
+		datasources := make([]models.Datasource, 0, len(value))
+		for _, item := range value {
+			datasourceMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			connectionDetails, _ := datasourceMap["connectionDetails"].(map[string]interface{})
+			datasources = append(datasources, models.Datasource{
+				DatasourceType:    getString(datasourceMap, "datasourceType"),
+				ConnectionDetails: connectionDetails,
+				DatasourceID:      getString(datasourceMap, "datasourceId"),
+				GatewayID:         getString(datasourceMap, "gatewayId"),
+			})
+		}
+
+		dataset.Parameters = parameters
+		dataset.Datasources = datasources
+
+		logger.LogDebug(fmt.Sprintf("Dataset %s has %d parameters and %d datasources", dataset.Name, len(parameters), len(datasources)))
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
+	}
+
+	return results
+}
+
 func (s *Service) backupDataflows(ctx context.Context, workspaceID string) ([]models.Dataflow, error) {
 	response, err := s.apiClient.GetDataflows(ctx, workspaceID)
 	if err != nil {
@@ -222,8 +529,12 @@ func (s *Service) backupDataflows(ctx context.Context, workspaceID string) ([]mo
 		}
 
 		dataflow := models.Dataflow{
-			ObjectID: getString(dataflowMap, "objectId"),
-			Name:     getString(dataflowMap, "name"),
+			ObjectID:     getString(dataflowMap, "objectId"),
+			Name:         getString(dataflowMap, "name"),
+			ConfiguredBy: getString(dataflowMap, "configuredBy"),
+		}
+		if description := getString(dataflowMap, "description"); description != "" {
+			dataflow.Description = &description
 		}
 		dataflows = append(dataflows, dataflow)
 	}
@@ -261,95 +572,902 @@ func (s *Service) backupDashboards(ctx context.Context, workspaceID string) ([]m
 	return dashboards, nil
 }
 
-func (s *Service) backupApps(ctx context.Context, workspaceID string) ([]models.App, error) {
+// backupDashboardTiles fills in the tiles of each dashboard
+func (s *Service) backupDashboardTiles(ctx context.Context, workspaceID string, dashboards []models.Dashboard) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(dashboards))
+
+	for i := range dashboards {
+		dashboard := &dashboards[i]
+		result := models.ItemResult{
+			ItemType: models.ItemTypeDashboard,
+			ItemID:   dashboard.ID,
+			Name:     dashboard.DisplayName,
+			Attempts: 1,
+		}
+
+		response, err := s.apiClient.GetDashboardTiles(ctx, workspaceID, dashboard.ID)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to get tiles for dashboard: %s", dashboard.DisplayName), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		value, _ := response["value"].([]interface{})
+		tiles := make([]models.Tile, 0, len(value))
+		for _, item := range value {
+			tileMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			tiles = append(tiles, models.Tile{
+				ID:            getString(tileMap, "id"),
+				Title:         getString(tileMap, "title"),
+				SubTitle:      getString(tileMap, "subTitle"),
+				ReportID:      getString(tileMap, "reportId"),
+				DatasetID:     getString(tileMap, "datasetId"),
+				RowSpan:       getInt(tileMap, "rowSpan"),
+				ColSpan:       getInt(tileMap, "colSpan"),
+				EmbedURL:      getString(tileMap, "embedUrl"),
+				Configuration: tileMap,
+			})
+		}
+		dashboard.Tiles = tiles
+
+		logger.LogDebug(fmt.Sprintf("Dashboard %s has %d tiles", dashboard.DisplayName, len(tiles)))
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// backupApps captures the apps published from a workspace with the reports and
+// dashboards they contain. Apps whose listing names another workspace are skipped
+// without further calls. The apps list does not reliably carry a workspaceId, so an
+// app without one belongs to the workspace when its reports were published from one
+// of the workspace's reports.
+func (s *Service) backupApps(ctx context.Context, workspaceID string, reports []models.Report) ([]models.App, []models.ItemResult, error) {
+	tenantApps, err := s.listApps(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workspaceReports := make(map[string]bool, len(reports))
+	for _, report := range reports {
+		workspaceReports[report.ID] = true
+	}
+
+	apps := make([]models.App, 0)
+	results := make([]models.ItemResult, 0)
+
+	for _, app := range tenantApps {
+		if app.WorkspaceID != "" && app.WorkspaceID != workspaceID {
+			continue
+		}
+
+		appReports, reportsErr := s.appReports(ctx, app.ID)
+		app.Reports = appReports
+
+		if app.WorkspaceID == "" {
+			// Without its reports there is no telling whether the app belongs here
+			if reportsErr != nil {
+				logger.LogError(fmt.Sprintf("Failed to get reports of app: %s", app.Name), reportsErr)
+				results = append(results, models.ItemResult{
+					ItemType: models.ItemTypeAppContents,
+					ItemID:   app.ID,
+					Name:     app.Name,
+					Outcome:  models.ItemOutcomeFailed,
+					Error:    fmt.Sprintf("could not check whether the app was published from this workspace: %v", reportsErr),
+					Attempts: 1,
+				})
+				continue
+			}
+
+			belongs := false
+			for _, report := range app.Reports {
+				if workspaceReports[report.OriginalReportID] {
+					belongs = true
+					break
+				}
+			}
+			if !belongs {
+				continue
+			}
+		}
+		app.WorkspaceID = workspaceID
+
+		result := models.ItemResult{
+			ItemType: models.ItemTypeAppContents,
+			ItemID:   app.ID,
+			Name:     app.Name,
+			Attempts: 1,
+		}
+
+		if reportsErr != nil {
+			logger.LogError(fmt.Sprintf("Failed to get reports of app: %s", app.Name), reportsErr)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = reportsErr.Error()
+			apps = append(apps, app)
+			results = append(results, result)
+			continue
+		}
+
+		appDashboards, err := s.apiClient.GetAppDashboards(ctx, app.ID)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to get dashboards of app: %s", app.Name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			apps = append(apps, app)
+			results = append(results, result)
+			continue
+		}
+		app.Dashboards = parseAppDashboards(appDashboards)
+
+		result.Outcome = models.ItemOutcomeExported
+		apps = append(apps, app)
+		results = append(results, result)
+	}
+
+	return apps, results, nil
+}
+
+// listApps returns the tenant's apps. During a run the list is fetched once and
+// shared by all of the run's workspaces; outside a run it is always fetched.
+func (s *Service) listApps(ctx context.Context) ([]models.App, error) {
+	s.appsMu.Lock()
+	cache := s.appCache
+	if cache != nil && cache.apps != nil {
+		apps := cache.apps
+		s.appsMu.Unlock()
+		return apps, nil
+	}
+	s.appsMu.Unlock()
+
 	response, err := s.apiClient.GetApps(ctx)
 	if err != nil {
-		return []models.App{}, nil // Apps are optional
+		return nil, err
+	}
+
+	value, _ := response["value"].([]interface{})
+	apps := make([]models.App, 0, len(value))
+	for _, item := range value {
+		appMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		apps = append(apps, models.App{
+			ID:          getString(appMap, "id"),
+			Name:        getString(appMap, "name"),
+			Description: getString(appMap, "description"),
+			PublishedBy: getString(appMap, "publishedBy"),
+			LastUpdate:  getString(appMap, "lastUpdate"),
+			WorkspaceID: getString(appMap, "workspaceId"),
+		})
+	}
+
+	if cache != nil {
+		s.appsMu.Lock()
+		cache.apps = apps
+		s.appsMu.Unlock()
+	}
+	return apps, nil
+}
+
+// appReports returns the reports of an app, fetched at most once per run
+func (s *Service) appReports(ctx context.Context, appID string) ([]models.AppReport, error) {
+	s.appsMu.Lock()
+	cache := s.appCache
+	if cache != nil {
+		if reports, ok := cache.reports[appID]; ok {
+			s.appsMu.Unlock()
+			return reports, nil
+		}
+	}
+	s.appsMu.Unlock()
+
+	response, err := s.apiClient.GetAppReports(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := parseAppReports(response)
+	if cache != nil {
+		s.appsMu.Lock()
+		cache.reports[appID] = reports
+		s.appsMu.Unlock()
+	}
+	return reports, nil
+}
+
+// startAppCache shares the tenant app listing between the workspaces of a run
+// until the returned function is called
+func (s *Service) startAppCache() func() {
+	cache := &appCache{reports: make(map[string][]models.AppReport)}
+	s.appsMu.Lock()
+	s.appCache = cache
+	s.appsMu.Unlock()
+
+	return func() {
+		s.appsMu.Lock()
+		if s.appCache == cache {
+			s.appCache = nil
+		}
+		s.appsMu.Unlock()
+	}
+}
+
+// parseWorkspaceSettings maps a workspace record, keeping every returned property in Settings
+func parseWorkspaceSettings(workspaceID string, workspaceData map[string]interface{}) models.WorkspaceSettings {
+	settings := models.WorkspaceSettings{
+		ID:                          workspaceID,
+		Name:                        getString(workspaceData, "name"),
+		Description:                 getString(workspaceData, "description"),
+		Type:                        getString(workspaceData, "type"),
+		State:                       getString(workspaceData, "state"),
+		IsReadOnly:                  getBool(workspaceData, "isReadOnly"),
+		IsOnDedicatedCapacity:       getBool(workspaceData, "isOnDedicatedCapacity"),
+		CapacityID:                  getString(workspaceData, "capacityId"),
+		DataflowStorageID:           getString(workspaceData, "dataflowStorageId"),
+		DefaultDatasetStorageFormat: getString(workspaceData, "defaultDatasetStorageFormat"),
+		Settings:                    make(map[string]interface{}),
+	}
+
+	for key, value := range workspaceData {
+		if strings.HasPrefix(key, "@odata") {
+			continue
+		}
+		settings.Settings[key] = value
+	}
+
+	return settings
+}
+
+func parseAppReports(response map[string]interface{}) []models.AppReport {
+	value, _ := response["value"].([]interface{})
+	reports := make([]models.AppReport, 0, len(value))
+	for _, item := range value {
+		reportMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		reports = append(reports, models.AppReport{
+			ID:               getString(reportMap, "id"),
+			Name:             getString(reportMap, "name"),
+			OriginalReportID: getString(reportMap, "originalReportObjectId"),
+			DatasetID:        getString(reportMap, "datasetId"),
+			WebURL:           getString(reportMap, "webUrl"),
+		})
+	}
+	return reports
+}
+
+func parseAppDashboards(response map[string]interface{}) []models.AppDashboard {
+	value, _ := response["value"].([]interface{})
+	dashboards := make([]models.AppDashboard, 0, len(value))
+	for _, item := range value {
+		dashboardMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		dashboards = append(dashboards, models.AppDashboard{
+			ID:          getString(dashboardMap, "id"),
+			DisplayName: getString(dashboardMap, "displayName"),
+			WebURL:      getString(dashboardMap, "webUrl"),
+		})
+	}
+	return dashboards
+}
+
+func (s *Service) backupWorkspaceUsers(ctx context.Context, workspaceID string) ([]models.WorkspaceUser, error) {
+	response, err := s.apiClient.GetWorkspaceUsers(ctx, workspaceID)
+	if err != nil {
+		return nil, err
 	}
 
 	value, ok := response["value"].([]interface{})
 	if !ok {
-		return []models.App{}, nil
+		return []models.WorkspaceUser{}, nil
 	}
 
-	apps := make([]models.App, 0)
+	users := make([]models.WorkspaceUser, 0, len(value))
 	for _, item := range value {
-		appMap, ok := item.(map[string]interface{})
+		userMap, ok := item.(map[string]interface{})
 		if !ok {
 			continue
 		}
 
-		// Filter apps for this workspace
-		if getString(appMap, "workspaceId") != workspaceID {
+		user := models.WorkspaceUser{
+			Identifier:           getString(userMap, "identifier"),
+			DisplayName:          getString(userMap, "displayName"),
+			EmailAddress:         getString(userMap, "emailAddress"),
+			GraphID:              getString(userMap, "graphId"),
+			PrincipalType:        getString(userMap, "principalType"),
+			GroupUserAccessRight: getString(userMap, "groupUserAccessRight"),
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// backupItemPermissions fills in the principals with direct access to each
+// dataset and report. Report users are only available through the admin API,
+// so they are skipped when the principal lacks admin permissions.
+func (s *Service) backupItemPermissions(ctx context.Context, workspaceID string, reports []models.Report, datasets []models.Dataset) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(reports)+len(datasets))
+
+	for i := range datasets {
+		dataset := &datasets[i]
+		result := models.ItemResult{
+			ItemType: models.ItemTypeDatasetPermissions,
+			ItemID:   dataset.ID,
+			Name:     dataset.Name,
+			Attempts: 1,
+		}
+
+		response, err := s.apiClient.GetDatasetUsers(ctx, workspaceID, dataset.ID)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to get users for dataset: %s", dataset.Name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
 			continue
 		}
 
-		app := models.App{
-			ID:          getString(appMap, "id"),
-			Name:        getString(appMap, "name"),
-			WorkspaceID: getString(appMap, "workspaceId"),
+		dataset.Users = parseItemUsers(response, "datasetUserAccessRight")
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
+	}
+
+	for i := range reports {
+		report := &reports[i]
+		result := models.ItemResult{
+			ItemType: models.ItemTypeReportPermissions,
+			ItemID:   report.ID,
+			Name:     report.Name,
+			Attempts: 1,
 		}
-		apps = append(apps, app)
+
+		response, err := s.apiClient.GetReportUsersAsAdmin(ctx, report.ID)
+		if api.IsAccessDenied(err) {
+			logger.LogDebug(fmt.Sprintf("No admin access to users of report: %s", report.Name))
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = "requires Power BI admin API permissions"
+			results = append(results, result)
+			continue
+		}
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to get users for report: %s", report.Name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		report.Users = parseItemUsers(response, "reportUserAccessRight")
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
 	}
 
-	return apps, nil
+	return results
 }
 
-func (s *Service) backupRefreshSchedules(ctx context.Context, workspaceID string, datasets []models.Dataset) ([]models.RefreshSchedule, error) {
-	schedules := make([]models.RefreshSchedule, 0)
+// backupSubscriptions reads the email subscriptions of each report and dashboard.
+// Subscriptions are only available through the admin API, so items are skipped
+// when the principal lacks admin permissions.
+func (s *Service) backupSubscriptions(ctx context.Context, reports []models.Report, dashboards []models.Dashboard) ([]models.Subscription, []models.ItemResult) {
+	subscriptions := []models.Subscription{}
+	results := make([]models.ItemResult, 0, len(reports)+len(dashboards))
 
-	for _, dataset := range datasets {
-		schedule, err := s.apiClient.GetRefreshSchedule(ctx, workspaceID, dataset.ID)
+	type subscribable struct {
+		id, name string
+		fetch    func(context.Context, string) (map[string]interface{}, error)
+	}
+	items := make([]subscribable, 0, len(reports)+len(dashboards))
+	for _, report := range reports {
+		items = append(items, subscribable{report.ID, report.Name, s.apiClient.GetReportSubscriptionsAsAdmin})
+	}
+	for _, dashboard := range dashboards {
+		items = append(items, subscribable{dashboard.ID, dashboard.DisplayName, s.apiClient.GetDashboardSubscriptionsAsAdmin})
+	}
+
+	for _, item := range items {
+		result := models.ItemResult{
+			ItemType: models.ItemTypeSubscription,
+			ItemID:   item.id,
+			Name:     item.name,
+			Attempts: 1,
+		}
+
+		response, err := item.fetch(ctx, item.id)
+		if api.IsAccessDenied(err) {
+			logger.LogDebug(fmt.Sprintf("No admin access to subscriptions of: %s", item.name))
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = "requires Power BI admin API permissions"
+			results = append(results, result)
+			continue
+		}
 		if err != nil {
-			logger.LogDebug(fmt.Sprintf("No refresh schedule for dataset: %s", dataset.Name))
+			logger.LogError(fmt.Sprintf("Failed to get subscriptions for: %s", item.name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		subscriptions = append(subscriptions, parseSubscriptions(response)...)
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
+	}
+
+	return subscriptions, results
+}
+
+// parseSubscriptions converts an admin subscriptions response into subscriptions
+func parseSubscriptions(response map[string]interface{}) []models.Subscription {
+	value, _ := response["value"].([]interface{})
+	subscriptions := make([]models.Subscription, 0, len(value))
+
+	for _, item := range value {
+		subMap, ok := item.(map[string]interface{})
+		if !ok {
 			continue
 		}
 
-		schedules = append(schedules, models.RefreshSchedule{
-			DatasetID:   dataset.ID,
-			DatasetName: dataset.Name,
-			Schedule:    schedule,
+		subscription := models.Subscription{
+			ID:                     getString(subMap, "id"),
+			Title:                  getString(subMap, "title"),
+			ArtifactID:             getString(subMap, "artifactId"),
+			ArtifactDisplayName:    getString(subMap, "artifactDisplayName"),
+			SubArtifactDisplayName: getString(subMap, "subArtifactDisplayName"),
+			ArtifactType:           getString(subMap, "artifactType"),
+			IsEnabled:              getBool(subMap, "isEnabled"),
+			Frequency:              getString(subMap, "frequency"),
+			StartDate:              getString(subMap, "startDate"),
+			EndDate:                getString(subMap, "endDate"),
+			LinkToContent:          getBool(subMap, "linkToContent"),
+			PreviewImage:           getBool(subMap, "previewImage"),
+			AttachmentFormat:       getString(subMap, "attachmentFormat"),
+			Users:                  []models.SubscriptionUser{},
+		}
+
+		users, _ := subMap["users"].([]interface{})
+		for _, u := range users {
+			userMap, ok := u.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			subscription.Users = append(subscription.Users, models.SubscriptionUser{
+				EmailAddress:  getString(userMap, "emailAddress"),
+				DisplayName:   getString(userMap, "displayName"),
+				Identifier:    getString(userMap, "identifier"),
+				PrincipalType: getString(userMap, "principalType"),
+			})
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions
+}
+
+// parseItemUsers reads a list of item users, taking the access right from accessKey
+func parseItemUsers(response map[string]interface{}, accessKey string) []models.ItemUser {
+	value, _ := response["value"].([]interface{})
+	users := make([]models.ItemUser, 0, len(value))
+	for _, item := range value {
+		userMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		users = append(users, models.ItemUser{
+			Identifier:    getString(userMap, "identifier"),
+			DisplayName:   getString(userMap, "displayName"),
+			EmailAddress:  getString(userMap, "emailAddress"),
+			GraphID:       getString(userMap, "graphId"),
+			PrincipalType: getString(userMap, "principalType"),
+			AccessRight:   getString(userMap, accessKey),
 		})
 	}
+	return users
+}
+
+// backupRefreshHistory records the most recent refreshes of each refreshable dataset
+func (s *Service) backupRefreshHistory(ctx context.Context, workspaceID string, datasets []models.Dataset) []models.ItemResult {
+	results := []models.ItemResult{}
+
+	for i := range datasets {
+		dataset := &datasets[i]
+		if !dataset.IsRefreshable {
+			continue
+		}
+
+		result := models.ItemResult{
+			ItemType: models.ItemTypeRefreshHistory,
+			ItemID:   dataset.ID,
+			Name:     dataset.Name,
+			Attempts: 1,
+		}
+
+		response, err := s.apiClient.GetRefreshHistory(ctx, workspaceID, dataset.ID, refreshHistoryDepth)
+		if err != nil {
+			logger.LogWarn(fmt.Sprintf("Failed to get refresh history for dataset %s: %v", dataset.Name, err))
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		value, _ := response["value"].([]interface{})
+		for _, item := range value {
+			entryMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			dataset.RefreshHistory = append(dataset.RefreshHistory, models.RefreshEntry{
+				RequestID:            getString(entryMap, "requestId"),
+				RefreshType:          getString(entryMap, "refreshType"),
+				StartTime:            getString(entryMap, "startTime"),
+				EndTime:              getString(entryMap, "endTime"),
+				Status:               getString(entryMap, "status"),
+				ServiceExceptionJSON: getString(entryMap, "serviceExceptionJson"),
+			})
+		}
+
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
+	}
 
-	return schedules, nil
+	return results
 }
 
-// backupReportsPBIX exports all reports as PBIX files
-func (s *Service) backupReportsPBIX(ctx context.Context, workspaceID string, reports []models.Report, backupDir string) (map[string]int, error) {
+// backupRefreshSchedules reads the refresh schedule of every refreshable dataset and
+// the cache refresh schedule of every dataset with DirectQuery or LiveConnection tables.
+// Composite datasets are refreshable and have DirectQuery tables, so they get both.
+func (s *Service) backupRefreshSchedules(ctx context.Context, workspaceID string, datasets []models.Dataset) ([]models.RefreshSchedule, []models.ItemResult) {
+	schedules := make([]models.RefreshSchedule, 0)
+	results := make([]models.ItemResult, 0, len(datasets))
+
+	for _, dataset := range datasets {
+		result := models.ItemResult{
+			ItemType: models.ItemTypeRefreshSchedule,
+			ItemID:   dataset.ID,
+			Name:     dataset.Name,
+			Attempts: 1,
+		}
+
+		var found []string
+		var failures []string
+
+		if dataset.IsRefreshable {
+			schedule, err := s.apiClient.GetRefreshSchedule(ctx, workspaceID, dataset.ID)
+			switch {
+			case err == nil:
+				schedules = append(schedules, models.RefreshSchedule{
+					DatasetID:   dataset.ID,
+					DatasetName: dataset.Name,
+					StorageMode: models.StorageModeImport,
+					Schedule:    schedule,
+				})
+				found = append(found, models.StorageModeImport)
+			case !scheduleNotApplicable(err):
+				failures = append(failures, fmt.Sprintf("refresh schedule: %v", err))
+			}
+		}
+
+		// The dataset listing does not tell import and composite datasets apart, so the
+		// DirectQuery schedule is read for every dataset; 400/404 means it has none
+		schedule, err := s.apiClient.GetDirectQueryRefreshSchedule(ctx, workspaceID, dataset.ID)
+		switch {
+		case err == nil:
+			schedules = append(schedules, models.RefreshSchedule{
+				DatasetID:   dataset.ID,
+				DatasetName: dataset.Name,
+				StorageMode: models.StorageModeDirectQuery,
+				Schedule:    schedule,
+			})
+			found = append(found, models.StorageModeDirectQuery)
+		case !scheduleNotApplicable(err):
+			failures = append(failures, fmt.Sprintf("DirectQuery refresh schedule: %v", err))
+		}
+
+		switch {
+		case len(failures) > 0:
+			logger.LogError(fmt.Sprintf("❌ Failed to backup refresh schedule for dataset: %s", dataset.Name), nil)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = strings.Join(failures, "; ")
+		case len(found) == 0:
+			logger.LogDebug(fmt.Sprintf("No refresh schedule for dataset: %s", dataset.Name))
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = "dataset has no refresh schedule"
+		default:
+			logger.LogDebug(fmt.Sprintf("Refresh schedules (%s) for dataset: %s", strings.Join(found, ", "), dataset.Name))
+			result.Outcome = models.ItemOutcomeExported
+		}
+		results = append(results, result)
+	}
+
+	return schedules, results
+}
+
+// scheduleNotApplicable reports whether a refresh schedule request failed because the
+// dataset has no schedule of that kind, as opposed to a real failure
+func scheduleNotApplicable(err error) bool {
+	return api.IsNotFound(err) || api.IsUnsupported(err)
+}
+
+// backupReportsPBIX exports all reports, retrying failed exports. Power BI reports
+// are saved as PBIX under pbix/ and paginated reports as RDL under rdl/.
+// Files are named after the sanitized report name plus the report ID; the returned
+// artifacts map each file back to its report and dataset.
+func (s *Service) backupReportsPBIX(ctx context.Context, workspaceID string, reports []models.Report, datasets []models.Dataset, backupDir string, opts Options) ([]models.ItemResult, []models.ArtifactFile, error) {
 	if len(reports) == 0 {
-		return map[string]int{"succeeded": 0, "failed": 0}, nil
+		return []models.ItemResult{}, []models.ArtifactFile{}, nil
 	}
 
-	// Create PBIX directory
-	pbixDir := filepath.Join(backupDir, "pbix")
-	if err := os.MkdirAll(pbixDir, 0755); err != nil {
-		logger.LogError(fmt.Sprintf("Failed to create PBIX directory: %s", pbixDir), err)
-		return nil, err
+	// Create PBIX and RDL directories
+	for _, dir := range []string{"pbix", "rdl"} {
+		if err := os.MkdirAll(filepath.Join(backupDir, dir), 0755); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to create %s directory in: %s", dir, backupDir), err)
+			return nil, nil, err
+		}
+	}
+
+	datasetNames := make(map[string]string, len(datasets))
+	for _, dataset := range datasets {
+		datasetNames[dataset.ID] = dataset.Name
 	}
 
-	succeeded := 0
-	failed := 0
+	results := make([]models.ItemResult, 0, len(reports))
+	artifacts := make([]models.ArtifactFile, 0, len(reports))
 
 	for _, report := range reports {
 		logger.LogInfo(fmt.Sprintf("📥 Exporting report: %s", report.Name))
 
-		// Create output path for PBIX file
-		pbixFile := filepath.Join(pbixDir, fmt.Sprintf("%s.pbix", report.Name))
+		// Paginated reports export as RDL, everything else as PBIX
+		dir, ext, itemType := "pbix", ".pbix", models.ItemTypeReportPBIX
+		if report.ReportType == models.ReportTypePaginated {
+			dir, ext, itemType = "rdl", ".rdl", models.ItemTypeReportRDL
+		}
+
+		// Create output path for the report file
+		fileName := storage.ArtifactFileName(report.Name, report.ID, ext)
+		pbixFile := filepath.Join(backupDir, dir, fileName)
+
+		result := models.ItemResult{
+			ItemType: itemType,
+			ItemID:   report.ID,
+			Name:     report.Name,
+		}
+		artifact := models.ArtifactFile{
+			File:        filepath.ToSlash(filepath.Join(dir, fileName)),
+			ReportID:    report.ID,
+			ReportName:  report.Name,
+			ReportType:  report.ReportType,
+			DatasetID:   report.DatasetID,
+			DatasetName: datasetNames[report.DatasetID],
+		}
+
+		// A resumed backup keeps the files the checkpoint records as exported before it
+		// was interrupted, as long as they still have the recorded size
+		if size, ok := opts.ExportedFiles[report.ID]; ok && fileHasSize(pbixFile, size) {
+			logger.LogInfo(fmt.Sprintf("♻️  Reusing exported report: %s", report.Name))
+			result.Outcome = models.ItemOutcomeExported
+			results = append(results, result)
+			artifacts = append(artifacts, artifact)
+			continue
+		}
 
 		// Export the report
-		success, err := s.apiClient.ExportReport(ctx, workspaceID, report.ID, pbixFile)
-		if err != nil || !success {
-			logger.LogError(fmt.Sprintf("❌ Failed to export report: %s", report.Name), err)
-			failed++
+		attempts, lastErr := withRetry(ctx, report.Name, func() error {
+			success, err := s.apiClient.ExportReport(ctx, workspaceID, report.ID, pbixFile)
+			if err == nil && !success {
+				err = fmt.Errorf("export returned no data")
+			}
+			return err
+		})
+		result.Attempts = attempts
+
+		if lastErr != nil {
+			logger.LogError(fmt.Sprintf("❌ Failed to export report: %s", report.Name), lastErr)
+			os.Remove(pbixFile) // Don't leave a truncated file behind
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = lastErr.Error()
+			results = append(results, result)
 			continue
 		}
 
+		if opts.OnExported != nil {
+			if info, err := os.Stat(pbixFile); err == nil {
+				opts.OnExported(report.ID, info.Size())
+			}
+		}
+
 		logger.LogInfo(fmt.Sprintf("✅ Report exported successfully: %s", report.Name))
-		succeeded++
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
+		artifacts = append(artifacts, artifact)
+	}
+
+	return results, artifacts, nil
+}
+
+// fileHasSize reports whether path is a non-empty file of the given size
+func fileHasSize(path string, size int64) bool {
+	info, err := os.Stat(path)
+	return err == nil && size > 0 && info.Size() == size
+}
+
+// backupDataflowDefinitions downloads the model.json definition of each dataflow
+func (s *Service) backupDataflowDefinitions(ctx context.Context, workspaceID string, dataflows []models.Dataflow, backupDir string) ([]models.ItemResult, error) {
+	if len(dataflows) == 0 {
+		return []models.ItemResult{}, nil
+	}
+
+	dataflowDir := filepath.Join(backupDir, "dataflows")
+	if err := os.MkdirAll(dataflowDir, 0755); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to create dataflow directory: %s", dataflowDir), err)
+		return nil, err
+	}
+
+	results := make([]models.ItemResult, 0, len(dataflows))
+
+	for i := range dataflows {
+		dataflow := &dataflows[i]
+		logger.LogInfo(fmt.Sprintf("📥 Exporting dataflow: %s", dataflow.Name))
+
+		fileName := storage.ArtifactFileName(dataflow.Name, dataflow.ObjectID, ".json")
+		definitionFile := filepath.Join(dataflowDir, fileName)
+
+		result := models.ItemResult{
+			ItemType: models.ItemTypeDataflow,
+			ItemID:   dataflow.ObjectID,
+			Name:     dataflow.Name,
+		}
+
+		attempts, err := withRetry(ctx, dataflow.Name, func() error {
+			return s.apiClient.ExportDataflow(ctx, workspaceID, dataflow.ObjectID, definitionFile)
+		})
+		result.Attempts = attempts
+
+		if err != nil {
+			logger.LogError(fmt.Sprintf("❌ Failed to export dataflow: %s", dataflow.Name), err)
+			os.Remove(definitionFile)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		dataflow.DefinitionFile = filepath.ToSlash(filepath.Join("dataflows", fileName))
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
 	}
 
-	return map[string]int{"succeeded": succeeded, "failed": failed}, nil
+	return results, nil
+}
+
+// backupModelDefinitions saves the TMDL definition of each semantic model
+// through the Fabric getDefinition API
+func (s *Service) backupModelDefinitions(ctx context.Context, workspaceID string, datasets []models.Dataset, backupDir string) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(datasets))
+
+	for i := range datasets {
+		dataset := &datasets[i]
+		logger.LogInfo(fmt.Sprintf("📥 Exporting model definition: %s", dataset.Name))
+
+		result := models.ItemResult{
+			ItemType: models.ItemTypeModelDefinition,
+			ItemID:   dataset.ID,
+			Name:     dataset.Name,
+		}
+
+		var parts []models.DefinitionPart
+		attempts, err := withRetry(ctx, dataset.Name, func() error {
+			var err error
+			parts, err = s.apiClient.GetItemDefinition(ctx, workspaceID, dataset.ID, modelDefinitionFormat)
+			return err
+		})
+		result.Attempts = attempts
+
+		if err != nil {
+			// Some models (e.g. push or Excel-based datasets) cannot export a definition
+			switch {
+			case api.IsUnsupported(err):
+				logger.LogWarn(fmt.Sprintf("⚠️  Model definition not supported for dataset: %s", dataset.Name))
+				result.Outcome = models.ItemOutcomeSkipped
+				result.Error = fmt.Sprintf("model definition not supported for this dataset: %v", err)
+			case api.IsAccessDenied(err):
+				logger.LogWarn(fmt.Sprintf("⚠️  Model definition not available for dataset: %s", dataset.Name))
+				result.Outcome = models.ItemOutcomeSkipped
+				result.Error = fmt.Sprintf("no permission to read the model definition: %v", err)
+			default:
+				logger.LogError(fmt.Sprintf("❌ Failed to export model definition: %s", dataset.Name), err)
+				result.Outcome = models.ItemOutcomeFailed
+				result.Error = err.Error()
+			}
+			results = append(results, result)
+			continue
+		}
+
+		relDir := filepath.Join("models", storage.ArtifactFileName(dataset.Name, dataset.ID, ""))
+		if err := storage.SaveDefinitionParts(filepath.Join(backupDir, relDir), parts); err != nil {
+			logger.LogError(fmt.Sprintf("❌ Failed to save model definition: %s", dataset.Name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		dataset.DefinitionDir = filepath.ToSlash(relDir)
+		result.Outcome = models.ItemOutcomeExported
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// withRetry runs an export up to maxExportAttempts times with a growing delay,
+// stopping early on non-transient errors or when ctx is cancelled. Returns the number of attempts made and the last error.
+func withRetry(ctx context.Context, name string, export func() error) (int, error) {
+	var lastErr error
+	for attempt := 1; attempt <= maxExportAttempts; attempt++ {
+		lastErr = export()
+		if lastErr == nil {
+			return attempt, nil
+		}
+		// Only throttling, server and network errors can go away on retry
+		if !api.IsTransient(lastErr) {
+			return attempt, lastErr
+		}
+		if attempt < maxExportAttempts {
+			logger.LogWarn(fmt.Sprintf("Export attempt %d/%d failed for %s: %v", attempt, maxExportAttempts, name, lastErr))
+			select {
+			case <-ctx.Done():
+				return attempt, ctx.Err()
+			case <-time.After(time.Duration(attempt) * exportRetryDelay):
+			}
+		}
+	}
+	return maxExportAttempts, lastErr
+}
+
+// componentFailure records a component listing that could not be backed up
+func componentFailure(component string, err error) models.ItemResult {
+	return models.ItemResult{
+		ItemType: models.ItemTypeComponent,
+		Name:     component,
+		Outcome:  models.ItemOutcomeFailed,
+		Error:    err.Error(),
+		Attempts: 1,
+	}
+}
+
+// summarizeStatus derives the overall backup status from the item results.
+// A backup with no failures is Complete, one where nothing succeeded is Failed.
+func summarizeStatus(items []models.ItemResult) models.BackupStatus {
+	failed := countOutcome(items, models.ItemOutcomeFailed)
+	if failed == 0 {
+		return models.BackupStatusComplete
+	}
+	if failed == len(items) {
+		return models.BackupStatusFailed
+	}
+	return models.BackupStatusPartial
+}
+
+func countOutcome(items []models.ItemResult, outcome models.ItemOutcome) int {
+	count := 0
+	for _, item := range items {
+		if item.Outcome == outcome {
+			count++
+		}
+	}
+	return count
 }
 
 // Helper functions
@@ -360,6 +1478,13 @@ func getString(m map[string]interface{}, key string) string {
 	return ""
 }
 
+func getInt(m map[string]interface{}, key string) int {
+	if val, ok := m[key].(float64); ok {
+		return int(val)
+	}
+	return 0
+}
+
 func getBool(m map[string]interface{}, key string) bool {
 	if val, ok := m[key].(bool); ok {
 		return val
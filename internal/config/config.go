@@ -14,12 +14,15 @@ type Settings struct {
 	PowerBITenantID     string
 
 	// API Configuration
-	APIBaseURL   string
-	Resource     string
-	AuthorityURL string
+	APIBaseURL       string
+	FabricAPIBaseURL string
+	Resource         string
+	AuthorityURL     string
 
 	// Storage
 	BackupPath string
+	// CleanupIncompleteBackups removes backups left behind by interrupted runs on startup
+	CleanupIncompleteBackups bool
 
 	// Server
 	Debug bool
@@ -33,14 +36,16 @@ func LoadConfig() (*Settings, error) {
 	_ = godotenv.Load()
 
 	settings := &Settings{
-		PowerBIClientID:     getEnv("POWERBI_CLIENT_ID", ""),
-		PowerBIClientSecret: getEnv("POWERBI_CLIENT_SECRET", ""),
-		PowerBITenantID:     getEnv("POWERBI_TENANT_ID", ""),
-		APIBaseURL:          getEnv("API_BASE_URL", "https://api.powerbi.com/v1.0/myorg"),
-		Resource:            "https://analysis.windows.net/powerbi/api",
-		AuthorityURL:        "https://login.microsoftonline.com",
-		BackupPath:          getEnv("BACKUP_PATH", "./backups"),
-		Debug:               getEnv("DEBUG", "false") == "true",
+		PowerBIClientID:          getEnv("POWERBI_CLIENT_ID", ""),
+		PowerBIClientSecret:      getEnv("POWERBI_CLIENT_SECRET", ""),
+		PowerBITenantID:          getEnv("POWERBI_TENANT_ID", ""),
+		APIBaseURL:               getEnv("API_BASE_URL", "https://api.powerbi.com/v1.0/myorg"),
+		FabricAPIBaseURL:         getEnv("FABRIC_API_BASE_URL", "https://api.fabric.microsoft.com/v1"),
+		Resource:                 "https://analysis.windows.net/powerbi/api",
+		AuthorityURL:             "https://login.microsoftonline.com",
+		BackupPath:               getEnv("BACKUP_PATH", "./backups"),
+		CleanupIncompleteBackups: getEnv("CLEANUP_INCOMPLETE_BACKUPS", "false") == "true",
+		Debug:                    getEnv("DEBUG", "false") == "true",
 	}
 
 	AppSettings = settings
@@ -2,14 +2,23 @@ package models
 
 import "time"
 
+// Report types returned by the reports API
+const (
+	ReportTypePowerBI   = "PowerBIReport"
+	ReportTypePaginated = "PaginatedReport"
+)
+
 // Report represents a Power BI report
 type Report struct {
-	ID        string      `json:"id"`
-	Name      string      `json:"name"`
-	DatasetID string      `json:"datasetId"`
-	EmbedURL  string      `json:"embedUrl"`
-	WebURL    string      `json:"webUrl"`
-	Pages     []ReportPage `json:"pages,omitempty"`
+	ID                 string       `json:"id"`
+	Name               string       `json:"name"`
+	ReportType         string       `json:"reportType,omitempty"`
+	DatasetID          string       `json:"datasetId"`
+	DatasetWorkspaceID string       `json:"datasetWorkspaceId,omitempty"` // Set when the dataset lives in another workspace
+	EmbedURL           string       `json:"embedUrl"`
+	WebURL             string       `json:"webUrl"`
+	Pages              []ReportPage `json:"pages,omitempty"`
+	Users              []ItemUser   `json:"users,omitempty"`
 }
 
 // ReportPage represents a report page
@@ -21,65 +30,517 @@ type ReportPage struct {
 
 // Dataset represents a Power BI dataset
 type Dataset struct {
-	ID                 string  `json:"id"`
-	Name               string  `json:"name"`
-	ConfigRefreshType  *string `json:"configuredBy,omitempty"`
-	IsRefreshable      bool    `json:"isRefreshable"`
-	IsEffectiveIdentityRequired bool `json:"isEffectiveIdentityRequired"`
-	IsEffectiveIdentityRolesRequired bool `json:"isEffectiveIdentityRolesRequired"`
+	ID                               string             `json:"id"`
+	Name                             string             `json:"name"`
+	Description                      string             `json:"description,omitempty"`
+	WebURL                           string             `json:"webUrl,omitempty"`
+	ConfigRefreshType                *string            `json:"configuredBy,omitempty"`
+	CreatedDate                      string             `json:"createdDate,omitempty"`
+	TargetStorageMode                string             `json:"targetStorageMode,omitempty"`
+	IsRefreshable                    bool               `json:"isRefreshable"`
+	IsEffectiveIdentityRequired      bool               `json:"isEffectiveIdentityRequired"`
+	IsEffectiveIdentityRolesRequired bool               `json:"isEffectiveIdentityRolesRequired"`
+	IsOnPremGatewayRequired          bool               `json:"isOnPremGatewayRequired"`
+	AddRowsAPIEnabled                bool               `json:"addRowsAPIEnabled"`
+	Endorsement                      string             `json:"endorsement,omitempty"`
+	CertifiedBy                      string             `json:"certifiedBy,omitempty"`
+	SensitivityLabelID               string             `json:"sensitivityLabelId,omitempty"`
+	Parameters                       []DatasetParameter `json:"parameters,omitempty"`
+	Datasources                      []Datasource       `json:"datasources,omitempty"`
+	Users                            []ItemUser         `json:"users,omitempty"`
+	RefreshHistory                   []RefreshEntry     `json:"refreshHistory,omitempty"` // Most recent first
+	UpstreamDataflows                []UpstreamDataflow `json:"upstreamDataflows,omitempty"`
+	DefinitionDir                    string             `json:"definitionDir,omitempty"` // Model definition parts, relative to the backup directory
+}
+
+// UpstreamDataflow identifies a dataflow a dataset loads from
+type UpstreamDataflow struct {
+	DataflowID  string `json:"dataflowObjectId"`
+	WorkspaceID string `json:"workspaceObjectId"`
+}
+
+// RefreshEntry is one entry of a dataset's refresh history
+type RefreshEntry struct {
+	RequestID            string `json:"requestId,omitempty"`
+	RefreshType          string `json:"refreshType"`
+	StartTime            string `json:"startTime,omitempty"`
+	EndTime              string `json:"endTime,omitempty"`
+	Status               string `json:"status"`
+	ServiceExceptionJSON string `json:"serviceExceptionJson,omitempty"`
+}
+
+// ItemUser represents a principal with direct access to a dataset or report
+type ItemUser struct {
+	Identifier    string `json:"identifier"`
+	DisplayName   string `json:"displayName,omitempty"`
+	EmailAddress  string `json:"emailAddress,omitempty"`
+	GraphID       string `json:"graphId,omitempty"`
+	PrincipalType string `json:"principalType"`
+	AccessRight   string `json:"accessRight"` // datasetUserAccessRight or reportUserAccessRight
+}
+
+// DefinitionPart is one file of a Fabric item definition, with its payload decoded
+type DefinitionPart struct {
+	Path    string
+	Payload []byte
+}
+
+// DatasetParameter represents a Power Query parameter of a dataset
+type DatasetParameter struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	IsRequired   bool   `json:"isRequired"`
+	CurrentValue string `json:"currentValue"`
+}
+
+// Datasource represents a datasource a dataset connects to
+type Datasource struct {
+	DatasourceType    string                 `json:"datasourceType"`
+	ConnectionDetails map[string]interface{} `json:"connectionDetails"`
+	DatasourceID      string                 `json:"datasourceId,omitempty"`
+	GatewayID         string                 `json:"gatewayId,omitempty"`
 }
 
 // Dataflow represents a Power BI dataflow
 type Dataflow struct {
-	ObjectID    string  `json:"objectId"`
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
+	ObjectID       string  `json:"objectId"`
+	Name           string  `json:"name"`
+	Description    *string `json:"description,omitempty"`
+	ConfiguredBy   string  `json:"configuredBy,omitempty"`
+	DefinitionFile string  `json:"definitionFile,omitempty"` // model.json, relative to the backup directory
+}
+
+// FabricItem represents a Fabric item (notebook, lakehouse, data pipeline, ...)
+// that the Power BI endpoints don't list
+type FabricItem struct {
+	ID            string `json:"id"`
+	DisplayName   string `json:"displayName"`
+	Description   string `json:"description,omitempty"`
+	Type          string `json:"type"`
+	DefinitionDir string `json:"definitionDir,omitempty"` // Definition parts, relative to the backup directory
 }
 
 // Dashboard represents a Power BI dashboard
 type Dashboard struct {
-	ID           string `json:"id"`
-	DisplayName  string `json:"displayName"`
-	IsReadOnly   bool   `json:"isReadOnly"`
-	EmbedURL     string `json:"embedUrl,omitempty"`
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	IsReadOnly  bool   `json:"isReadOnly"`
+	EmbedURL    string `json:"embedUrl,omitempty"`
+	Tiles       []Tile `json:"tiles,omitempty"`
 }
 
-// App represents a Power BI app
+// Tile represents a dashboard tile and the report or dataset it is pinned from
+type Tile struct {
+	ID            string                 `json:"id"`
+	Title         string                 `json:"title,omitempty"`
+	SubTitle      string                 `json:"subTitle,omitempty"`
+	ReportID      string                 `json:"reportId,omitempty"`
+	DatasetID     string                 `json:"datasetId,omitempty"`
+	RowSpan       int                    `json:"rowSpan"`
+	ColSpan       int                    `json:"colSpan"`
+	EmbedURL      string                 `json:"embedUrl,omitempty"`
+	Configuration map[string]interface{} `json:"configuration,omitempty"` // Raw tile record as returned by the API
+}
+
+// App represents a Power BI app published from a workspace
 type App struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	PublishedBy string         `json:"publishedBy,omitempty"`
+	LastUpdate  string         `json:"lastUpdate,omitempty"`
+	WorkspaceID string         `json:"workspaceId,omitempty"`
+	Reports     []AppReport    `json:"reports,omitempty"`
+	Dashboards  []AppDashboard `json:"dashboards,omitempty"`
+}
+
+// AppReport represents a report included in an app
+type AppReport struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	OriginalReportID string `json:"originalReportObjectId,omitempty"` // Report in the workspace the app was published from
+	DatasetID        string `json:"datasetId,omitempty"`
+	WebURL           string `json:"webUrl,omitempty"`
+}
+
+// AppDashboard represents a dashboard included in an app
+type AppDashboard struct {
 	ID          string `json:"id"`
-	Name        string `json:"name"`
-	WorkspaceID string `json:"workspaceId,omitempty"`
+	DisplayName string `json:"displayName"`
+	WebURL      string `json:"webUrl,omitempty"`
+}
+
+// Storage modes a refresh schedule applies to
+const (
+	StorageModeImport      = "Import"
+	StorageModeDirectQuery = "DirectQuery" // DirectQuery and LiveConnection datasets
+)
+
+// Subscription represents an email subscription to a report or dashboard
+type Subscription struct {
+	ID                     string             `json:"id"`
+	Title                  string             `json:"title"`
+	ArtifactID             string             `json:"artifactId"`
+	ArtifactDisplayName    string             `json:"artifactDisplayName"`
+	SubArtifactDisplayName string             `json:"subArtifactDisplayName,omitempty"` // Report page
+	ArtifactType           string             `json:"artifactType"`                     // Report or Dashboard
+	IsEnabled              bool               `json:"isEnabled"`
+	Frequency              string             `json:"frequency"`
+	StartDate              string             `json:"startDate,omitempty"`
+	EndDate                string             `json:"endDate,omitempty"`
+	LinkToContent          bool               `json:"linkToContent"`
+	PreviewImage           bool               `json:"previewImage"`
+	AttachmentFormat       string             `json:"attachmentFormat,omitempty"`
+	Users                  []SubscriptionUser `json:"users"`
+}
+
+// SubscriptionUser represents a recipient of a subscription
+type SubscriptionUser struct {
+	EmailAddress  string `json:"emailAddress"`
+	DisplayName   string `json:"displayName,omitempty"`
+	Identifier    string `json:"identifier,omitempty"`
+	PrincipalType string `json:"principalType,omitempty"`
 }
 
 // RefreshSchedule represents a dataset refresh schedule
 type RefreshSchedule struct {
 	DatasetID   string                 `json:"datasetId"`
 	DatasetName string                 `json:"datasetName"`
+	StorageMode string                 `json:"storageMode,omitempty"` // Empty in older backups, meaning Import
 	Schedule    map[string]interface{} `json:"schedule"`
 }
 
-// WorkspaceSettings represents workspace configuration
+// WorkspaceUser represents a principal with a role in a workspace
+type WorkspaceUser struct {
+	Identifier           string `json:"identifier"`
+	DisplayName          string `json:"displayName,omitempty"`
+	EmailAddress         string `json:"emailAddress,omitempty"`
+	GraphID              string `json:"graphId,omitempty"`
+	PrincipalType        string `json:"principalType"`
+	GroupUserAccessRight string `json:"groupUserAccessRight"`
+}
+
+// RunStatus is the state of a tenant-wide backup run or of a workspace within it
+type RunStatus string
+
+const (
+	RunStatusPending    RunStatus = "Pending"
+	RunStatusInProgress RunStatus = "InProgress"
+	RunStatusCompleted  RunStatus = "Completed"
+	RunStatusFailed     RunStatus = "Failed"
+)
+
+// BackupRun is the checkpoint of a tenant-wide backup run. A rerun with the
+// same run ID skips completed workspaces and resumes interrupted ones.
+type BackupRun struct {
+	RunID      string         `json:"runId"`
+	StartedAt  time.Time      `json:"startedAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+	Status     RunStatus      `json:"status"`
+	Components []string       `json:"components"`
+	Workspaces []WorkspaceRun `json:"workspaces"`
+}
+
+// WorkspaceRun tracks one workspace of a backup run
+type WorkspaceRun struct {
+	WorkspaceID   string     `json:"workspaceId"`
+	WorkspaceName string     `json:"workspaceName"`
+	Status        RunStatus  `json:"status"`
+	StagingTime   *time.Time `json:"stagingTime,omitempty"` // Timestamp of the backup being written, reused on resume
+	BackupPath    string     `json:"backupPath,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	// ExportedFiles maps each report exported into the staging directory to its
+	// file size; only these files are reused when the workspace is resumed
+	ExportedFiles map[string]int64 `json:"exportedFiles,omitempty"`
+}
+
+// WorkspaceRef identifies a workspace selected for a tenant-wide backup
+type WorkspaceRef struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type,omitempty"`
+	State      string `json:"state,omitempty"`
+	CapacityID string `json:"capacityId,omitempty"`
+}
+
+// GatewayInventory is a tenant-level backup of gateways, their datasources and
+// the datasets bound to them. Credentials are never returned by the API and are
+// not part of the inventory.
+type GatewayInventory struct {
+	Timestamp time.Time           `json:"timestamp"`
+	Status    BackupStatus        `json:"status"`
+	Items     []ItemResult        `json:"items"`
+	Gateways  []Gateway           `json:"gateways"`
+	Bindings  []DatasourceBinding `json:"bindings"`
+}
+
+// Gateway represents an on-premises data gateway cluster
+type Gateway struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Type        string              `json:"type"`
+	Annotation  string              `json:"gatewayAnnotation,omitempty"`
+	Datasources []GatewayDatasource `json:"datasources"`
+}
+
+// GatewayDatasource represents a datasource configured on a gateway
+type GatewayDatasource struct {
+	ID                string `json:"id"`
+	GatewayID         string `json:"gatewayId"`
+	DatasourceName    string `json:"datasourceName"`
+	DatasourceType    string `json:"datasourceType"`
+	ConnectionDetails string `json:"connectionDetails"` // JSON encoded, without credentials
+	CredentialType    string `json:"credentialType,omitempty"`
+}
+
+// DatasourceBinding records a dataset bound to a gateway datasource
+type DatasourceBinding struct {
+	WorkspaceID       string `json:"workspaceId"`
+	WorkspaceName     string `json:"workspaceName,omitempty"`
+	DatasetID         string `json:"datasetId"`
+	DatasetName       string `json:"datasetName"`
+	GatewayID         string `json:"gatewayId"`
+	DatasourceID      string `json:"datasourceId"`
+	DatasourceType    string `json:"datasourceType"`
+	ConnectionDetails string `json:"connectionDetails"`
+}
+
+// PipelineInventory is a tenant-level backup of the deployment pipelines the
+// principal has access to. Deployment rules are not exposed by the API and are
+// not part of the inventory; a restore lists them for manual follow-up.
+type PipelineInventory struct {
+	Timestamp time.Time    `json:"timestamp"`
+	Status    BackupStatus `json:"status"`
+	Items     []ItemResult `json:"items"`
+	Pipelines []Pipeline   `json:"pipelines"`
+}
+
+// Pipeline represents a deployment pipeline
+type Pipeline struct {
+	ID          string              `json:"id"`
+	DisplayName string              `json:"displayName"`
+	Description string              `json:"description,omitempty"`
+	Stages      []PipelineStage     `json:"stages"`
+	Operations  []PipelineOperation `json:"operations"`
+	Users       []PipelineUser      `json:"users,omitempty"`
+}
+
+// PipelineUser is a principal with access to a deployment pipeline
+type PipelineUser struct {
+	Identifier    string `json:"identifier"`
+	PrincipalType string `json:"principalType"`
+	AccessRight   string `json:"accessRight"`
+}
+
+// PipelineStage represents a pipeline stage and the workspace assigned to it
+type PipelineStage struct {
+	Order         int    `json:"order"` // 0 development, 1 test, 2 production
+	WorkspaceID   string `json:"workspaceId,omitempty"`
+	WorkspaceName string `json:"workspaceName,omitempty"`
+}
+
+// PipelineOperation represents a deployment or stage assignment in a pipeline's history
+type PipelineOperation struct {
+	ID                 string `json:"id"`
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	LastUpdatedTime    string `json:"lastUpdatedTime,omitempty"`
+	ExecutionStartTime string `json:"executionStartTime,omitempty"`
+	ExecutionEndTime   string `json:"executionEndTime,omitempty"`
+	SourceStageOrder   int    `json:"sourceStageOrder"`
+	TargetStageOrder   int    `json:"targetStageOrder"`
+	Note               string `json:"note,omitempty"`
+}
+
+// Lineage node types
+const (
+	LineageNodeReport    = "Report"
+	LineageNodeDataset   = "Dataset"
+	LineageNodeDataflow  = "Dataflow"
+	LineageNodeDashboard = "Dashboard"
+)
+
+// LineageEdge records that the source item depends on the target item
+type LineageEdge struct {
+	SourceType        string `json:"sourceType"`
+	SourceID          string `json:"sourceId"`
+	SourceWorkspaceID string `json:"sourceWorkspaceId"`
+	TargetType        string `json:"targetType"`
+	TargetID          string `json:"targetId"`
+	TargetWorkspaceID string `json:"targetWorkspaceId"`
+}
+
+// LineageGraph is the dependency graph across a set of workspace backups
+type LineageGraph struct {
+	Timestamp  time.Time          `json:"timestamp"`
+	Nodes      []LineageNode      `json:"nodes"`
+	Edges      []LineageEdge      `json:"edges"`
+	Workspaces []LineageWorkspace `json:"workspaces"`
+}
+
+// LineageNode is an item of the lineage graph. External nodes are not part of
+// any backup in the graph, so restoring the graph's backups won't bring them back.
+type LineageNode struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	WorkspaceID string `json:"workspaceId"`
+	External    bool   `json:"external"`
+}
+
+// LineageWorkspace lists what a workspace backup depends on outside its own workspace
+type LineageWorkspace struct {
+	WorkspaceID          string        `json:"workspaceId"`
+	WorkspaceName        string        `json:"workspaceName"`
+	BackupPath           string        `json:"backupPath"`
+	ExternalDependencies []LineageEdge `json:"externalDependencies"`
+}
+
+// PreflightReport records what a backup of the checked workspaces will and won't include
+type PreflightReport struct {
+	Timestamp  time.Time            `json:"timestamp"`
+	Workspaces []WorkspacePreflight `json:"workspaces"`
+}
+
+// WorkspacePreflight is the preflight result of a single workspace
+type WorkspacePreflight struct {
+	WorkspaceID   string          `json:"workspaceId"`
+	WorkspaceName string          `json:"workspaceName"`
+	Accessible    bool            `json:"accessible"`
+	Error         string          `json:"error,omitempty"`
+	CapacityID    string          `json:"capacityId,omitempty"`
+	CapacityType  string          `json:"capacityType,omitempty"` // Dedicated or Shared
+	Reports       int             `json:"reports"`
+	Datasets      int             `json:"datasets"`
+	Dataflows     int             `json:"dataflows"`
+	Dashboards    int             `json:"dashboards"`
+	Items         []PreflightItem `json:"items,omitempty"`
+}
+
+// PreflightItem is the exportability check of a single item
+type PreflightItem struct {
+	ItemType   string `json:"itemType"`
+	ItemID     string `json:"itemId"`
+	Name       string `json:"name"`
+	Exportable bool   `json:"exportable"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// WorkspaceSettings represents workspace configuration.
+// Settings holds every property the API returned for the workspace, including
+// ones without a field of their own.
 type WorkspaceSettings struct {
-	ID       string                 `json:"id"`
-	Name     string                 `json:"name"`
-	Type     string                 `json:"type,omitempty"`
-	State    string                 `json:"state,omitempty"`
-	IsReadOnly bool                 `json:"isReadOnly,omitempty"`
-	Settings map[string]interface{} `json:"settings,omitempty"`
+	ID                          string                 `json:"id"`
+	Name                        string                 `json:"name"`
+	Description                 string                 `json:"description,omitempty"`
+	Type                        string                 `json:"type,omitempty"`
+	State                       string                 `json:"state,omitempty"`
+	IsReadOnly                  bool                   `json:"isReadOnly,omitempty"`
+	IsOnDedicatedCapacity       bool                   `json:"isOnDedicatedCapacity,omitempty"`
+	CapacityID                  string                 `json:"capacityId,omitempty"`
+	DataflowStorageID           string                 `json:"dataflowStorageId,omitempty"`
+	DefaultDatasetStorageFormat string                 `json:"defaultDatasetStorageFormat,omitempty"`
+	Settings                    map[string]interface{} `json:"settings,omitempty"`
+}
+
+// BackupStatus represents the overall outcome of a backup
+type BackupStatus string
+
+const (
+	BackupStatusComplete BackupStatus = "Complete"
+	BackupStatusPartial  BackupStatus = "Partial"
+	BackupStatusFailed   BackupStatus = "Failed"
+)
+
+// ItemOutcome represents the outcome of backing up a single item
+type ItemOutcome string
+
+const (
+	ItemOutcomeExported ItemOutcome = "Exported"
+	ItemOutcomeSkipped  ItemOutcome = "Skipped"
+	ItemOutcomeFailed   ItemOutcome = "Failed"
+	ItemOutcomeRestored ItemOutcome = "Restored"
+)
+
+// Item types recorded in ItemResult
+const (
+	ItemTypeComponent          = "Component"
+	ItemTypeReportPBIX         = "ReportPBIX"
+	ItemTypeReportPages        = "ReportPages"
+	ItemTypeRefreshSchedule    = "RefreshSchedule"
+	ItemTypeDataflow           = "Dataflow"
+	ItemTypeDashboard          = "Dashboard"
+	ItemTypeDashboardTile      = "DashboardTile"
+	ItemTypeDatasetConnections = "DatasetConnections"
+	ItemTypeWorkspaceUser      = "WorkspaceUser"
+	ItemTypeDatasetPermissions = "DatasetPermissions"
+	ItemTypeReportPermissions  = "ReportPermissions"
+	ItemTypeReportRDL          = "ReportRDL"
+	ItemTypeModelDefinition    = "ModelDefinition"
+	ItemTypeRefreshHistory     = "RefreshHistory"
+	ItemTypeSubscription       = "Subscription"
+	ItemTypeAppContents        = "AppContents"
+	ItemTypeGateway            = "Gateway"
+	ItemTypeDatasourceBinding  = "DatasourceBinding"
+	ItemTypeCapacityAssignment = "CapacityAssignment"
+	ItemTypeWorkspaceSettings  = "WorkspaceSettings"
+	ItemTypePipeline           = "Pipeline"
+	ItemTypePipelineStage      = "PipelineStage"
+	ItemTypeFabricItem         = "FabricItem"
+	ItemTypePipelineUser       = "PipelineUser"
+	ItemTypePipelineRules      = "PipelineDeploymentRules"
+)
+
+// ItemResult records what happened to a single item during a backup.
+// Component listings (reports, datasets, ...) are recorded with ItemTypeComponent.
+type ItemResult struct {
+	ItemType string      `json:"itemType"`
+	ItemID   string      `json:"itemId,omitempty"`
+	Name     string      `json:"name"`
+	Outcome  ItemOutcome `json:"outcome"`
+	Error    string      `json:"error,omitempty"`
+	Attempts int         `json:"attempts"`
+}
+
+// ArtifactFile maps a file stored in the backup to the item it was exported from
+type ArtifactFile struct {
+	File        string `json:"file"` // Path relative to the backup directory
+	ReportID    string `json:"reportId"`
+	ReportName  string `json:"reportName"`
+	ReportType  string `json:"reportType,omitempty"`
+	DatasetID   string `json:"datasetId,omitempty"`
+	DatasetName string `json:"datasetName,omitempty"`
 }
 
 // CompleteBackup represents a complete backup of a workspace
 type CompleteBackup struct {
-	Timestamp         time.Time           `json:"timestamp"`
-	WorkspaceID       string              `json:"workspaceId"`
-	WorkspaceName     string              `json:"workspaceName"`
-	Reports           []Report            `json:"reports"`
-	Datasets          []Dataset           `json:"datasets"`
-	Dataflows         []Dataflow          `json:"dataflows"`
-	Dashboards        []Dashboard         `json:"dashboards"`
-	Apps              []App               `json:"apps"`
-	RefreshSchedules  []RefreshSchedule   `json:"refreshSchedules"`
-	WorkspaceSettings WorkspaceSettings   `json:"workspaceSettings"`
+	Timestamp         time.Time         `json:"timestamp"`
+	WorkspaceID       string            `json:"workspaceId"`
+	WorkspaceName     string            `json:"workspaceName"`
+	Status            BackupStatus      `json:"status"`
+	Components        []string          `json:"components,omitempty"` // Components selected for this backup
+	Items             []ItemResult      `json:"items"`
+	Artifacts         []ArtifactFile    `json:"artifacts"`
+	Reports           []Report          `json:"reports"`
+	Datasets          []Dataset         `json:"datasets"`
+	Dataflows         []Dataflow        `json:"dataflows"`
+	FabricItems       []FabricItem      `json:"fabricItems,omitempty"`
+	Dashboards        []Dashboard       `json:"dashboards"`
+	Apps              []App             `json:"apps"`
+	RefreshSchedules  []RefreshSchedule `json:"refreshSchedules"`
+	Subscriptions     []Subscription    `json:"subscriptions,omitempty"`
+	Lineage           []LineageEdge     `json:"lineage,omitempty"` // Dependencies of the workspace's items
+	Users             []WorkspaceUser   `json:"users"`
+	WorkspaceSettings WorkspaceSettings `json:"workspaceSettings"`
+}
+
+// RestoreResult represents the outcome of restoring a backup into a workspace
+type RestoreResult struct {
+	Timestamp         time.Time    `json:"timestamp"`
+	BackupPath        string       `json:"backupPath"`
+	SourceWorkspaceID string       `json:"sourceWorkspaceId"`
+	TargetWorkspaceID string       `json:"targetWorkspaceId"`
+	Items             []ItemResult `json:"items"`
 }
 
 // APIResponse represents a generic API response
@@ -0,0 +1,109 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/veeam/powerbi-backup-go/internal/logger"
+	"github.com/veeam/powerbi-backup-go/internal/models"
+	"github.com/veeam/powerbi-backup-go/internal/storage"
+)
+
+// itemRestoreOrder creates storage items before the items that read from them
+// and data pipelines last, since they orchestrate the others. Unlisted types go
+// in between.
+var itemRestoreOrder = map[string]int{
+	"Lakehouse":    0,
+	"Warehouse":    0,
+	"Eventhouse":   0,
+	"KQLDatabase":  1,
+	"Environment":  1,
+	"DataPipeline": 3,
+}
+
+// emptyItemTypes hold data rather than code, so without a definition in the
+// backup they are recreated empty for the data to be reloaded. Warehouses never
+// have one. An empty item is recorded as Skipped since its schema and data are
+// not restored.
+var emptyItemTypes = map[string]bool{
+	"Lakehouse":  true,
+	"Warehouse":  true,
+	"Eventhouse": true,
+}
+
+// restoreFabricItems recreates Fabric items from their definition parts. References
+// inside a definition (e.g. a notebook's default lakehouse) keep pointing at the
+// source items.
+func (s *Service) restoreFabricItems(ctx context.Context, workspaceID, backupPath string, items []models.FabricItem) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(items))
+	if len(items) == 0 {
+		return results
+	}
+
+	logger.LogInfo(fmt.Sprintf("🧱 Restoring %d Fabric items...", len(items)))
+
+	ordered := make([]models.FabricItem, len(items))
+	copy(ordered, items)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return restoreRank(ordered[i].Type) < restoreRank(ordered[j].Type)
+	})
+
+	for _, item := range ordered {
+		result := models.ItemResult{
+			ItemType: models.ItemTypeFabricItem,
+			ItemID:   item.ID,
+			Name:     item.DisplayName,
+			Attempts: 1,
+		}
+
+		var parts []models.DefinitionPart
+		if item.DefinitionDir != "" {
+			loaded, err := storage.LoadDefinitionParts(filepath.Join(backupPath, filepath.FromSlash(item.DefinitionDir)))
+			if err != nil {
+				logger.LogError(fmt.Sprintf("❌ Failed to read %s definition: %s", item.Type, item.DisplayName), err)
+				result.Outcome = models.ItemOutcomeFailed
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+			parts = loaded
+		} else if !emptyItemTypes[item.Type] {
+			logger.LogWarn(fmt.Sprintf("No definition in backup for %s: %s", item.Type, item.DisplayName))
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = "no definition in backup"
+			results = append(results, result)
+			continue
+		}
+
+		if _, err := s.apiClient.CreateItem(ctx, workspaceID, item.DisplayName, item.Type, parts); err != nil {
+			logger.LogError(fmt.Sprintf("❌ Failed to create %s: %s", item.Type, item.DisplayName), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		if len(parts) == 0 {
+			logger.LogWarn(fmt.Sprintf("⚠️  Created empty %s, its schema and data have to be reloaded: %s", item.Type, item.DisplayName))
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = fmt.Sprintf("created an empty %s; schema/data not restorable, reload them manually", item.Type)
+			results = append(results, result)
+			continue
+		}
+
+		logger.LogInfo(fmt.Sprintf("✅ Created %s: %s", item.Type, item.DisplayName))
+		result.Outcome = models.ItemOutcomeRestored
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func restoreRank(itemType string) int {
+	if rank, ok := itemRestoreOrder[itemType]; ok {
+		return rank
+	}
+	return 2
+}
@@ -2,9 +2,14 @@ package restore
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/veeam/powerbi-backup-go/internal/api"
 	"github.com/veeam/powerbi-backup-go/internal/logger"
@@ -12,6 +17,31 @@ import (
 	"github.com/veeam/powerbi-backup-go/internal/storage"
 )
 
+const (
+	// importTimeout bounds how long a single import is awaited
+	importTimeout = 10 * time.Minute
+	// importPollInterval is the delay between import status checks
+	importPollInterval = 5 * time.Second
+	// capacityAssignmentTimeout bounds how long a capacity assignment is awaited
+	capacityAssignmentTimeout = 5 * time.Minute
+)
+
+// Options selects the optional parts of a restore
+type Options struct {
+	// RestoreAccess re-grants the workspace roles recorded in the backup
+	RestoreAccess bool
+	// RestoreItemPermissions re-grants direct dataset and report access recorded in the backup
+	RestoreItemPermissions bool
+	// AssignCapacity assigns the target workspace to the capacity recorded in the
+	// backup, or to its entry in CapacityMapping, before anything is imported
+	AssignCapacity bool
+	// CapacityMapping maps source capacity IDs to target capacity IDs
+	CapacityMapping map[string]string
+	// ApplyWorkspaceSettings reapplies the default dataset storage format and
+	// dataflow storage recorded in the backup to the target workspace
+	ApplyWorkspaceSettings bool
+}
+
 // Service orchestrates the restore of Power BI components
 type Service struct {
 	apiClient      *api.Client
@@ -27,7 +57,7 @@ func NewService(apiClient *api.Client, storageService *storage.StorageService) *
 }
 
 // RestoreWorkspace restores a workspace from backup
-func (s *Service) RestoreWorkspace(ctx context.Context, targetWorkspaceID, backupPath string) error {
+func (s *Service) RestoreWorkspace(ctx context.Context, targetWorkspaceID, backupPath string, opts Options) (*models.RestoreResult, error) {
 	logger.LogInfo(fmt.Sprintf("Starting restore for workspace: %s", targetWorkspaceID))
 	logger.LogInfo(fmt.Sprintf("Restoring from backup: %s", backupPath))
 
@@ -35,53 +65,158 @@ func (s *Service) RestoreWorkspace(ctx context.Context, targetWorkspaceID, backu
 	backup, err := s.storageService.LoadBackup(backupPath)
 	if err != nil {
 		logger.LogError("Failed to load backup", err)
-		return err
+		return nil, err
 	}
 
 	logger.LogInfo(fmt.Sprintf("Loaded backup from: %s", backup.Timestamp.Format("2006-01-02 15:04:05")))
 	logger.LogInfo(fmt.Sprintf("Original workspace: %s (%s)", backup.WorkspaceName, backup.WorkspaceID))
 
+	result := &models.RestoreResult{
+		Timestamp:         time.Now(),
+		BackupPath:        backupPath,
+		SourceWorkspaceID: backup.WorkspaceID,
+		TargetWorkspaceID: targetWorkspaceID,
+		Items:             []models.ItemResult{},
+	}
+
+	mapping := newRestoreMapping()
+
+	// Move the workspace onto capacity first - large models fail to import on shared capacity
+	if opts.AssignCapacity {
+		result.Items = append(result.Items, s.assignCapacity(ctx, targetWorkspaceID, backup.WorkspaceSettings, opts.CapacityMapping))
+	}
+
+	// Settings go after the capacity - the large dataset storage format needs one
+	if opts.ApplyWorkspaceSettings {
+		result.Items = append(result.Items, s.restoreWorkspaceSettings(ctx, targetWorkspaceID, backup.WorkspaceSettings)...)
+	}
+
+	// Restore dataflows first - datasets in the PBIX files may load from them
+	result.Items = append(result.Items, s.restoreDataflows(ctx, targetWorkspaceID, backupPath, backup.Dataflows)...)
+
+	// Restore Fabric items - lakehouses and warehouses may back the imported models
+	result.Items = append(result.Items, s.restoreFabricItems(ctx, targetWorkspaceID, backupPath, backup.FabricItems)...)
+
 	// Restore reports via PBIX files
-	if err := s.restoreReportsPBIX(ctx, targetWorkspaceID, backupPath); err != nil {
+	pbixResults, err := s.restoreReportsPBIX(ctx, targetWorkspaceID, backupPath, backup, mapping)
+	if err != nil {
 		logger.LogError("Failed to restore reports", err)
-		return err
+		result.Items = append(result.Items, models.ItemResult{
+			ItemType: models.ItemTypeComponent,
+			Name:     "pbix",
+			Outcome:  models.ItemOutcomeFailed,
+			Error:    err.Error(),
+		})
+		s.saveRestoreReport(result)
+		return result, err
 	}
+	result.Items = append(result.Items, pbixResults...)
+
+	// Recreate the datasets no PBIX brought back from their model definitions
+	result.Items = append(result.Items, s.restoreModelDefinitions(ctx, targetWorkspaceID, backupPath, backup.Datasets, mapping)...)
+
+	// Reapply dataset parameters and datasources so restored datasets connect as before
+	result.Items = append(result.Items, s.restoreDatasetConnections(ctx, targetWorkspaceID, backup.Datasets, mapping)...)
 
 	// Restore refresh schedules
-	if err := s.restoreRefreshSchedules(ctx, targetWorkspaceID, backup.RefreshSchedules); err != nil {
-		logger.LogError("Failed to restore refresh schedules", err)
-		// Don't fail entire restore if schedules fail
-		logger.LogWarn("Continuing without refresh schedules")
+	result.Items = append(result.Items, s.restoreRefreshSchedules(ctx, targetWorkspaceID, backup.RefreshSchedules, mapping)...)
+
+	// Restore dashboards onto the imported reports
+	result.Items = append(result.Items, s.restoreDashboards(ctx, targetWorkspaceID, backup, mapping)...)
+
+	// List subscriptions against the restored reports and dashboards
+	result.Items = append(result.Items, s.restoreSubscriptions(backup, mapping)...)
+
+	// List what each app needs to be republished with
+	result.Items = append(result.Items, s.restoreApps(backup, mapping)...)
+
+	// Re-grant workspace access
+	if opts.RestoreAccess {
+		result.Items = append(result.Items, s.restoreWorkspaceUsers(ctx, targetWorkspaceID, backup.Users)...)
 	}
 
-	logger.LogInfo("✅ Workspace restore completed successfully")
-	return nil
+	// Re-grant direct access to the restored datasets and reports
+	if opts.RestoreItemPermissions {
+		result.Items = append(result.Items, s.restoreItemPermissions(ctx, targetWorkspaceID, backup, mapping)...)
+	}
+
+	failed := 0
+	for _, item := range result.Items {
+		if item.Outcome == models.ItemOutcomeFailed {
+			logger.LogWarn(fmt.Sprintf("   ❌ %s %s: %s", item.ItemType, item.Name, item.Error))
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		logger.LogWarn(fmt.Sprintf("⚠️  Workspace restore completed with %d failed items", failed))
+	} else {
+		logger.LogInfo("✅ Workspace restore completed successfully")
+	}
+
+	s.saveRestoreReport(result)
+	return result, nil
 }
 
-// restoreReportsPBIX restores reports by importing PBIX files
-func (s *Service) restoreReportsPBIX(ctx context.Context, workspaceID, backupPath string) error {
-	logger.LogInfo("📄 Starting PBIX restoration...")
+// saveRestoreReport keeps the restore result next to the backup; a restore that
+// ran is not failed because its report could not be written
+func (s *Service) saveRestoreReport(result *models.RestoreResult) {
+	if _, err := s.storageService.SaveRestoreReport(result); err != nil {
+		logger.LogWarn(fmt.Sprintf("Failed to save restore report: %v", err))
+	}
+}
 
-	// Find PBIX directory
-	pbixDir := filepath.Join(backupPath, "pbix")
-	if _, err := os.Stat(pbixDir); os.IsNotExist(err) {
-		logger.LogWarn("No PBIX directory found in backup")
-		return nil
+// pbixImport is a report file from the backup and the dataset name to import it as.
+// Paginated reports are RDL files and have no dataset.
+type pbixImport struct {
+	path        string
+	paginated   bool
+	reportID    string
+	reportName  string
+	datasetID   string
+	datasetName string
+}
+
+// restoreMapping maps items of the source workspace to the items created
+// for them in the target workspace
+type restoreMapping struct {
+	reports    map[string]string // Source report ID -> target report ID
+	datasets   map[string]string // Source dataset ID -> target dataset ID
+	dashboards map[string]string // Source dashboard ID -> target dashboard ID
+}
+
+func newRestoreMapping() *restoreMapping {
+	return &restoreMapping{
+		reports:    make(map[string]string),
+		datasets:   make(map[string]string),
+		dashboards: make(map[string]string),
 	}
+}
 
-	// Get all PBIX files
-	files, err := filepath.Glob(filepath.Join(pbixDir, "*.pbix"))
+// restoreReportsPBIX restores reports by importing PBIX files. Once the imports
+// finish, the IDs of the created reports and datasets are recorded in mapping.
+func (s *Service) restoreReportsPBIX(ctx context.Context, workspaceID, backupPath string, backup *models.CompleteBackup, mapping *restoreMapping) ([]models.ItemResult, error) {
+	logger.LogInfo("📄 Starting PBIX restoration...")
+
+	imports, err := s.findPBIXImports(backupPath, backup)
 	if err != nil {
 		logger.LogError("Failed to find PBIX files", err)
-		return err
+		return nil, err
 	}
 
-	if len(files) == 0 {
+	if len(imports) == 0 {
 		logger.LogWarn("No PBIX files found to restore")
-		return nil
+		return []models.ItemResult{}, nil
 	}
 
-	logger.LogInfo(fmt.Sprintf("Found %d PBIX files to restore", len(files)))
+	logger.LogInfo(fmt.Sprintf("Found %d PBIX files to restore", len(imports)))
+
+	// Several reports can share a dataset; the first import of a dataset becomes the
+	// restored dataset and the other reports are rebound to it. Importing the report
+	// named like its dataset first keeps the dataset's original name.
+	sort.SliceStable(imports, func(i, j int) bool {
+		return imports[i].reportName == imports[i].datasetName && imports[j].reportName != imports[j].datasetName
+	})
 
 	// Get existing datasets to detect duplicates
 	existingDatasets := make(map[string]bool)
@@ -98,100 +233,1142 @@ func (s *Service) restoreReportsPBIX(ctx context.Context, workspaceID, backupPat
 		}
 	}
 
+	// Get existing reports to detect duplicate paginated report names
+	existingReports := make(map[string]bool)
+	reportsResp, err := s.apiClient.GetReports(ctx, workspaceID)
+	if err == nil {
+		if value, ok := reportsResp["value"].([]interface{}); ok {
+			for _, item := range value {
+				if report, ok := item.(map[string]interface{}); ok {
+					if name, ok := report["name"].(string); ok {
+						existingReports[name] = true
+					}
+				}
+			}
+		}
+	}
+
 	// Import each PBIX file
 	imported := 0
 	failed := 0
+	results := make([]models.ItemResult, 0, len(imports))
+	pending := make(map[int]string) // Index into results and imports -> import ID
 
-	for _, pbixFile := range files {
+	for _, pbix := range imports {
+		pbixFile := pbix.path
 		fileName := filepath.Base(pbixFile)
-		datasetName := fileName[:len(fileName)-5] // Remove .pbix extension
 
 		logger.LogInfo(fmt.Sprintf("📥 Importing: %s", fileName))
 
-		// Check for duplicates
-		finalName := datasetName
-		if existingDatasets[datasetName] {
-			counter := 1
-			for existingDatasets[fmt.Sprintf("%s_%d", datasetName, counter)] {
-				counter++
-			}
-			finalName = fmt.Sprintf("%s_%d", datasetName, counter)
-			logger.LogInfo(fmt.Sprintf("⚠️  Duplicate detected - renaming to: %s", finalName))
+		result := models.ItemResult{
+			ItemType: models.ItemTypeReportPBIX,
+			ItemID:   pbix.reportID,
+			Name:     pbix.reportName,
+			Attempts: 1,
 		}
 
-		// Import PBIX
-		success, err := s.apiClient.ImportPBIX(ctx, workspaceID, pbixFile, finalName)
-		if err != nil || !success {
+		// Check for duplicates and import
+		var importID string
+		var finalName string
+		if pbix.paginated {
+			result.ItemType = models.ItemTypeReportRDL
+			finalName = uniqueName(pbix.reportName, existingReports)
+			importID, err = s.apiClient.ImportRDL(ctx, workspaceID, pbixFile, finalName)
+		} else {
+			// The import names both the report and its dataset
+			finalName = uniqueName(pbix.reportName, existingDatasets)
+			importID, err = s.apiClient.ImportPBIX(ctx, workspaceID, pbixFile, finalName)
+		}
+		if err != nil {
 			logger.LogError(fmt.Sprintf("❌ Failed to import: %s", fileName), err)
-			failed++
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
 			continue
 		}
 
 		logger.LogInfo(fmt.Sprintf("✅ Import queued successfully: %s", finalName))
-		existingDatasets[finalName] = true
-		imported++
+		if pbix.paginated {
+			existingReports[finalName] = true
+		} else {
+			existingDatasets[finalName] = true
+		}
+		result.Outcome = models.ItemOutcomeRestored
+		results = append(results, result)
+		if importID != "" {
+			pending[len(results)-1] = importID
+		}
+	}
+
+	// Wait for the queued imports in import order so later steps can address the
+	// new items by ID and each dataset maps to its first import
+	for i := range results {
+		importID, ok := pending[i]
+		if !ok {
+			continue
+		}
+		pbix := imports[i]
+		importResult, err := s.waitForImport(ctx, workspaceID, importID)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("❌ Import did not complete: %s", results[i].Name), err)
+			results[i].Outcome = models.ItemOutcomeFailed
+			results[i].Error = err.Error()
+			continue
+		}
+
+		var newReportID, newDatasetID string
+		if reports, ok := importResult["reports"].([]interface{}); ok && len(reports) > 0 {
+			if report, ok := reports[0].(map[string]interface{}); ok {
+				newReportID, _ = report["id"].(string)
+			}
+		}
+		if datasets, ok := importResult["datasets"].([]interface{}); ok && len(datasets) > 0 {
+			if dataset, ok := datasets[0].(map[string]interface{}); ok {
+				newDatasetID, _ = dataset["id"].(string)
+			}
+		}
+
+		if pbix.reportID != "" && newReportID != "" {
+			mapping.reports[pbix.reportID] = newReportID
+		}
+		if pbix.datasetID == "" || newDatasetID == "" {
+			continue
+		}
+
+		sharedDatasetID, restored := mapping.datasets[pbix.datasetID]
+		if !restored {
+			mapping.datasets[pbix.datasetID] = newDatasetID
+			continue
+		}
+
+		if err := s.rebindToSharedDataset(ctx, workspaceID, newReportID, newDatasetID, sharedDatasetID); err != nil {
+			logger.LogWarn(fmt.Sprintf("⚠️  Report %s keeps its own copy of the dataset: %v", pbix.reportName, err))
+			results[i].Error = fmt.Sprintf("not rebound to the shared dataset, kept its own copy: %v", err)
+		}
+	}
+
+	for _, result := range results {
+		if result.Outcome == models.ItemOutcomeRestored {
+			imported++
+		} else {
+			failed++
+		}
 	}
 
 	logger.LogInfo(fmt.Sprintf("PBIX restoration complete: %d imported, %d failed", imported, failed))
+	return results, nil
+}
+
+// uniqueName appends a counter to name when it is already taken (name -> name_1, name_2)
+func uniqueName(name string, existing map[string]bool) string {
+	if !existing[name] {
+		return name
+	}
+
+	counter := 1
+	for existing[fmt.Sprintf("%s_%d", name, counter)] {
+		counter++
+	}
+	finalName := fmt.Sprintf("%s_%d", name, counter)
+	logger.LogInfo(fmt.Sprintf("⚠️  Duplicate detected - renaming to: %s", finalName))
+	return finalName
+}
+
+// rebindToSharedDataset binds an imported report to the dataset restored for its
+// source dataset and deletes the duplicate dataset its import created
+func (s *Service) rebindToSharedDataset(ctx context.Context, workspaceID, reportID, duplicateDatasetID, sharedDatasetID string) error {
+	if reportID == "" {
+		return fmt.Errorf("import returned no report")
+	}
+
+	if err := s.apiClient.RebindReport(ctx, workspaceID, reportID, sharedDatasetID); err != nil {
+		return err
+	}
+	logger.LogInfo(fmt.Sprintf("🔗 Rebound report %s to shared dataset %s", reportID, sharedDatasetID))
+
+	if err := s.apiClient.DeleteDataset(ctx, workspaceID, duplicateDatasetID); err != nil {
+		logger.LogWarn(fmt.Sprintf("Failed to delete duplicate dataset %s: %v", duplicateDatasetID, err))
+	}
 	return nil
 }
 
-// restoreRefreshSchedules restores refresh schedules for datasets
-func (s *Service) restoreRefreshSchedules(ctx context.Context, workspaceID string, schedules []models.RefreshSchedule) error {
-	if len(schedules) == 0 {
-		logger.LogInfo("No refresh schedules to restore")
-		return nil
+// waitForImport polls an import until it succeeds, fails or times out
+func (s *Service) waitForImport(ctx context.Context, workspaceID, importID string) (map[string]interface{}, error) {
+	deadline := time.Now().Add(importTimeout)
+	for {
+		importResult, err := s.apiClient.GetImport(ctx, workspaceID, importID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch state, _ := importResult["importState"].(string); state {
+		case "Succeeded":
+			return importResult, nil
+		case "Failed":
+			return nil, fmt.Errorf("import %s failed", importID)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("import %s did not finish within %v", importID, importTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(importPollInterval):
+		}
 	}
+}
 
-	logger.LogInfo(fmt.Sprintf("Restoring %d refresh schedules...", len(schedules)))
+// CreateWorkspaceFromBackup creates a new workspace for restoring a backup into,
+// named after the backed-up workspace unless a name is given, and returns its ID.
+// Settings that can only be changed after creation are applied by RestoreWorkspace
+// with Options.ApplyWorkspaceSettings.
+func (s *Service) CreateWorkspaceFromBackup(ctx context.Context, backupPath, name string) (string, error) {
+	backup, err := s.storageService.LoadBackup(backupPath)
+	if err != nil {
+		logger.LogError("Failed to load backup", err)
+		return "", err
+	}
 
-	// Get current datasets in workspace
-	datasetsResp, err := s.apiClient.GetDatasets(ctx, workspaceID)
+	settings := backup.WorkspaceSettings
+	if name == "" {
+		name = backup.WorkspaceName
+	}
+
+	payload := map[string]interface{}{"name": name}
+	if settings.Description != "" {
+		payload["description"] = settings.Description
+	}
+
+	logger.LogInfo(fmt.Sprintf("Creating workspace: %s", name))
+	created, err := s.apiClient.CreateWorkspace(ctx, payload)
 	if err != nil {
-		logger.LogError("Failed to get datasets", err)
-		return err
+		logger.LogError(fmt.Sprintf("Failed to create workspace: %s", name), err)
+		return "", err
 	}
 
-	// Map dataset names to IDs
-	datasetNameToID := make(map[string]string)
-	if value, ok := datasetsResp["value"].([]interface{}); ok {
-		for _, item := range value {
-			if ds, ok := item.(map[string]interface{}); ok {
-				name, _ := ds["name"].(string)
-				id, _ := ds["id"].(string)
-				if name != "" && id != "" {
-					datasetNameToID[name] = id
-				}
+	createdMap, _ := created.(map[string]interface{})
+	workspaceID, _ := createdMap["id"].(string)
+	if workspaceID == "" {
+		return "", fmt.Errorf("create workspace response has no id")
+	}
+
+	logger.LogInfo(fmt.Sprintf("✅ Workspace created: %s (%s)", name, workspaceID))
+	return workspaceID, nil
+}
+
+// restoreWorkspaceSettings reapplies the workspace settings the API allows
+// changing. Type, state and read-only status are set by the service itself.
+func (s *Service) restoreWorkspaceSettings(ctx context.Context, workspaceID string, settings models.WorkspaceSettings) []models.ItemResult {
+	logger.LogInfo("Restoring workspace settings...")
+	var results []models.ItemResult
+
+	apply := func(name string, update func() error) {
+		result := models.ItemResult{
+			ItemType: models.ItemTypeWorkspaceSettings,
+			ItemID:   workspaceID,
+			Name:     name,
+			Attempts: 1,
+			Outcome:  models.ItemOutcomeRestored,
+		}
+		if err := update(); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to restore workspace setting: %s", name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+		} else {
+			logger.LogInfo(fmt.Sprintf("✅ Restored workspace setting: %s", name))
+		}
+		results = append(results, result)
+	}
+
+	if settings.DefaultDatasetStorageFormat != "" {
+		apply("defaultDatasetStorageFormat", func() error {
+			return s.apiClient.UpdateWorkspace(ctx, workspaceID, map[string]interface{}{
+				"defaultDatasetStorageFormat": settings.DefaultDatasetStorageFormat,
+			})
+		})
+	}
+
+	if settings.DataflowStorageID != "" {
+		apply("dataflowStorageId", func() error {
+			return s.apiClient.AssignToDataflowStorage(ctx, workspaceID, settings.DataflowStorageID)
+		})
+	}
+
+	return results
+}
+
+// assignCapacity assigns the target workspace to the source workspace's capacity
+// or to the capacity it is mapped to
+func (s *Service) assignCapacity(ctx context.Context, workspaceID string, settings models.WorkspaceSettings, capacityMapping map[string]string) models.ItemResult {
+	result := models.ItemResult{
+		ItemType: models.ItemTypeCapacityAssignment,
+		ItemID:   settings.CapacityID,
+		Name:     settings.Name,
+		Attempts: 1,
+	}
+
+	if settings.CapacityID == "" {
+		logger.LogInfo("Source workspace was on shared capacity - leaving capacity unchanged")
+		result.Outcome = models.ItemOutcomeSkipped
+		result.Error = "backup records no capacity"
+		return result
+	}
+
+	capacityID := settings.CapacityID
+	if mapped, ok := capacityMapping[capacityID]; ok && mapped != "" {
+		capacityID = mapped
+	}
+	result.ItemID = capacityID
+
+	logger.LogInfo(fmt.Sprintf("Assigning workspace to capacity: %s", capacityID))
+	if err := s.apiClient.AssignToCapacity(ctx, workspaceID, capacityID); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to assign workspace to capacity: %s", capacityID), err)
+		result.Outcome = models.ItemOutcomeFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	if err := s.waitForCapacityAssignment(ctx, workspaceID); err != nil {
+		logger.LogError(fmt.Sprintf("Capacity assignment did not complete: %s", capacityID), err)
+		result.Outcome = models.ItemOutcomeFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	logger.LogInfo(fmt.Sprintf("✅ Workspace assigned to capacity: %s", capacityID))
+	result.Outcome = models.ItemOutcomeRestored
+	return result
+}
+
+// waitForCapacityAssignment polls a workspace's capacity assignment until it completes, fails or times out
+func (s *Service) waitForCapacityAssignment(ctx context.Context, workspaceID string) error {
+	deadline := time.Now().Add(capacityAssignmentTimeout)
+	for {
+		status, err := s.apiClient.GetCapacityAssignmentStatus(ctx, workspaceID)
+		if err != nil {
+			return err
+		}
+
+		switch state, _ := status["status"].(string); state {
+		case "CompletedSuccessfully":
+			return nil
+		case "AssignmentFailed":
+			return fmt.Errorf("capacity assignment failed")
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("capacity assignment did not finish within %v", capacityAssignmentTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(importPollInterval):
+		}
+	}
+}
+
+// findPBIXImports lists the PBIX and RDL files to import using the artifact
+// mapping recorded in the backup. Backups taken before the mapping existed name
+// files after the report, so the dataset name is derived from the file name instead.
+func (s *Service) findPBIXImports(backupPath string, backup *models.CompleteBackup) ([]pbixImport, error) {
+	if len(backup.Artifacts) > 0 {
+		imports := make([]pbixImport, 0, len(backup.Artifacts))
+		for _, artifact := range backup.Artifacts {
+			ext := strings.ToLower(filepath.Ext(artifact.File))
+			if ext != ".pbix" && ext != ".rdl" {
+				continue
+			}
+
+			pbixFile := filepath.Join(backupPath, filepath.FromSlash(artifact.File))
+			if _, err := os.Stat(pbixFile); err != nil {
+				logger.LogWarn(fmt.Sprintf("Report file for %s missing from backup: %s", artifact.ReportName, artifact.File))
+				continue
 			}
+
+			datasetName := artifact.DatasetName
+			if datasetName == "" {
+				datasetName = artifact.ReportName
+			}
+			imports = append(imports, pbixImport{
+				path:        pbixFile,
+				paginated:   ext == ".rdl",
+				reportID:    artifact.ReportID,
+				reportName:  artifact.ReportName,
+				datasetID:   artifact.DatasetID,
+				datasetName: datasetName,
+			})
 		}
+		return imports, nil
+	}
+
+	// Find PBIX directory
+	pbixDir := filepath.Join(backupPath, "pbix")
+	if _, err := os.Stat(pbixDir); os.IsNotExist(err) {
+		logger.LogWarn("No PBIX directory found in backup")
+		return nil, nil
 	}
 
+	// Get all PBIX files
+	files, err := filepath.Glob(filepath.Join(pbixDir, "*.pbix"))
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make([]pbixImport, 0, len(files))
+	for _, pbixFile := range files {
+		fileName := filepath.Base(pbixFile)
+		name := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		imports = append(imports, pbixImport{
+			path:        pbixFile,
+			reportName:  name,
+			datasetName: name,
+		})
+	}
+	return imports, nil
+}
+
+// restoreRefreshSchedules restores refresh schedules onto the datasets this restore
+// created. Imports are named after their report, so a dataset in the target workspace
+// that shares the original name is unrelated and never receives a schedule.
+func (s *Service) restoreRefreshSchedules(ctx context.Context, workspaceID string, schedules []models.RefreshSchedule, mapping *restoreMapping) []models.ItemResult {
+	if len(schedules) == 0 {
+		logger.LogInfo("No refresh schedules to restore")
+		return []models.ItemResult{}
+	}
+
+	logger.LogInfo(fmt.Sprintf("Restoring %d refresh schedules...", len(schedules)))
+
 	restored := 0
+	skipped := 0
 	failed := 0
+	results := make([]models.ItemResult, 0, len(schedules))
 
 	// Restore schedules
 	for _, schedule := range schedules {
 		logger.LogInfo(fmt.Sprintf("Restoring schedule for dataset: %s", schedule.DatasetName))
 
-		// Find new dataset ID by name
-		newDatasetID, exists := datasetNameToID[schedule.DatasetName]
+		result := models.ItemResult{
+			ItemType: models.ItemTypeRefreshSchedule,
+			ItemID:   schedule.DatasetID,
+			Name:     schedule.DatasetName,
+			Outcome:  models.ItemOutcomeFailed,
+		}
+
+		newDatasetID, exists := mapping.datasets[schedule.DatasetID]
 		if !exists {
-			logger.LogWarn(fmt.Sprintf("Dataset not found in target workspace: %s", schedule.DatasetName))
-			failed++
+			logger.LogWarn(fmt.Sprintf("Dataset was not restored, skipping schedule: %s", schedule.DatasetName))
+			skipped++
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = "dataset was not restored"
+			results = append(results, result)
 			continue
 		}
 
 		// Update refresh schedule
-		if err := s.apiClient.UpdateRefreshSchedule(ctx, workspaceID, newDatasetID, schedule.Schedule); err != nil {
+		result.Attempts = 1
+		if err := s.updateRefreshSchedule(ctx, workspaceID, newDatasetID, schedule); err != nil {
 			logger.LogError(fmt.Sprintf("Failed to restore schedule for: %s", schedule.DatasetName), err)
 			failed++
+			result.Error = err.Error()
+			results = append(results, result)
 			continue
 		}
 
 		logger.LogInfo(fmt.Sprintf("✅ Schedule restored: %s", schedule.DatasetName))
 		restored++
+		result.Outcome = models.ItemOutcomeRestored
+		results = append(results, result)
+	}
+
+	logger.LogInfo(fmt.Sprintf("Refresh schedule restoration complete: %d restored, %d skipped, %d failed", restored, skipped, failed))
+	return results
+}
+
+// updateRefreshSchedule applies a backed up schedule through the endpoint that
+// matches the dataset's storage mode
+func (s *Service) updateRefreshSchedule(ctx context.Context, workspaceID, datasetID string, schedule models.RefreshSchedule) error {
+	// The GET response carries OData annotations the update endpoints reject
+	body := make(map[string]interface{}, len(schedule.Schedule))
+	for key, value := range schedule.Schedule {
+		if !strings.HasPrefix(key, "@odata") {
+			body[key] = value
+		}
+	}
+
+	if schedule.StorageMode == models.StorageModeDirectQuery {
+		return s.apiClient.UpdateDirectQueryRefreshSchedule(ctx, workspaceID, datasetID, body)
 	}
+	return s.apiClient.UpdateRefreshSchedule(ctx, workspaceID, datasetID, body)
+}
+
+// restoreDatasetConnections reapplies the parameters and datasources recorded
+// for each dataset to the dataset imported for it
+func (s *Service) restoreDatasetConnections(ctx context.Context, workspaceID string, datasets []models.Dataset, mapping *restoreMapping) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(datasets))
+
+	for _, dataset := range datasets {
+		if len(dataset.Parameters) == 0 && len(dataset.Datasources) == 0 {
+			continue
+		}
+
+		result := models.ItemResult{
+			ItemType: models.ItemTypeDatasetConnections,
+			ItemID:   dataset.ID,
+			Name:     dataset.Name,
+		}
+
+		targetDatasetID, ok := mapping.datasets[dataset.ID]
+		if !ok {
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = "dataset was not restored"
+			results = append(results, result)
+			continue
+		}
+
+		result.Attempts = 1
+		if err := s.applyDatasetConnections(ctx, workspaceID, targetDatasetID, dataset); err != nil {
+			logger.LogError(fmt.Sprintf("❌ Failed to restore connections for dataset: %s", dataset.Name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		logger.LogInfo(fmt.Sprintf("✅ Connections restored: %s", dataset.Name))
+		result.Outcome = models.ItemOutcomeRestored
+		results = append(results, result)
+	}
+
+	return results
+}
 
-	logger.LogInfo(fmt.Sprintf("Refresh schedule restoration complete: %d restored, %d failed", restored, failed))
+// applyDatasetConnections updates parameters first, since datasources are often
+// driven by parameters, then points any datasource that still differs from the
+// backup at the backed up connection
+func (s *Service) applyDatasetConnections(ctx context.Context, workspaceID, datasetID string, dataset models.Dataset) error {
+	if len(dataset.Parameters) > 0 {
+		updates := make([]map[string]interface{}, 0, len(dataset.Parameters))
+		for _, parameter := range dataset.Parameters {
+			updates = append(updates, map[string]interface{}{
+				"name":     parameter.Name,
+				"newValue": parameter.CurrentValue,
+			})
+		}
+
+		if err := s.apiClient.UpdateParameters(ctx, workspaceID, datasetID, updates); err != nil {
+			return fmt.Errorf("update parameters: %w", err)
+		}
+	}
+
+	if len(dataset.Datasources) == 0 {
+		return nil
+	}
+
+	currentResp, err := s.apiClient.GetDatasources(ctx, workspaceID, datasetID)
+	if err != nil {
+		return fmt.Errorf("get datasources: %w", err)
+	}
+
+	var current []map[string]interface{}
+	if value, ok := currentResp["value"].([]interface{}); ok {
+		for _, item := range value {
+			if ds, ok := item.(map[string]interface{}); ok {
+				current = append(current, ds)
+			}
+		}
+	}
+
+	// Pair backed up and current datasources by type, in order
+	used := make(map[int]bool)
+	updates := make([]map[string]interface{}, 0)
+	for _, backedUp := range dataset.Datasources {
+		for i, ds := range current {
+			if used[i] || ds["datasourceType"] != backedUp.DatasourceType {
+				continue
+			}
+			used[i] = true
+
+			currentDetails, _ := ds["connectionDetails"].(map[string]interface{})
+			if reflect.DeepEqual(currentDetails, backedUp.ConnectionDetails) {
+				break
+			}
+
+			updates = append(updates, map[string]interface{}{
+				"datasourceSelector": map[string]interface{}{
+					"datasourceType":    backedUp.DatasourceType,
+					"connectionDetails": currentDetails,
+				},
+				"connectionDetails": backedUp.ConnectionDetails,
+			})
+			break
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if err := s.apiClient.UpdateDatasources(ctx, workspaceID, datasetID, updates); err != nil {
+		return fmt.Errorf("update datasources: %w", err)
+	}
 	return nil
 }
+
+// restoreWorkspaceUsers grants the principals recorded in the backup their
+// workspace role, updating the role of principals that already have access
+func (s *Service) restoreWorkspaceUsers(ctx context.Context, workspaceID string, users []models.WorkspaceUser) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(users))
+	if len(users) == 0 {
+		logger.LogInfo("No workspace users to restore")
+		return results
+	}
+
+	logger.LogInfo(fmt.Sprintf("👥 Restoring access for %d principals...", len(users)))
+
+	// Current roles in the target workspace, by identifier
+	currentRoles := make(map[string]string)
+	currentResp, err := s.apiClient.GetWorkspaceUsers(ctx, workspaceID)
+	if err != nil {
+		logger.LogWarn(fmt.Sprintf("Failed to get current workspace users: %v", err))
+	} else if value, ok := currentResp["value"].([]interface{}); ok {
+		for _, item := range value {
+			if user, ok := item.(map[string]interface{}); ok {
+				identifier, _ := user["identifier"].(string)
+				role, _ := user["groupUserAccessRight"].(string)
+				currentRoles[strings.ToLower(identifier)] = role
+			}
+		}
+	}
+
+	for _, user := range users {
+		name := user.DisplayName
+		if name == "" {
+			name = user.Identifier
+		}
+
+		result := models.ItemResult{
+			ItemType: models.ItemTypeWorkspaceUser,
+			ItemID:   user.Identifier,
+			Name:     name,
+		}
+
+		currentRole, exists := currentRoles[strings.ToLower(user.Identifier)]
+		if exists && currentRole == user.GroupUserAccessRight {
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = "already has this role"
+			results = append(results, result)
+			continue
+		}
+
+		request := map[string]interface{}{
+			"identifier":           user.Identifier,
+			"principalType":        user.PrincipalType,
+			"groupUserAccessRight": user.GroupUserAccessRight,
+		}
+		if user.EmailAddress != "" {
+			request["emailAddress"] = user.EmailAddress
+		}
+
+		result.Attempts = 1
+		if exists {
+			err = s.apiClient.UpdateWorkspaceUser(ctx, workspaceID, request)
+		} else {
+			err = s.apiClient.AddWorkspaceUser(ctx, workspaceID, request)
+		}
+		if err != nil {
+			logger.LogError(fmt.Sprintf("❌ Failed to grant %s to %s", user.GroupUserAccessRight, name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		logger.LogInfo(fmt.Sprintf("✅ Granted %s to %s", user.GroupUserAccessRight, name))
+		result.Outcome = models.ItemOutcomeRestored
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// restoreItemPermissions grants the direct dataset access recorded in the backup
+// on the imported datasets. The API cannot grant Write/ReadWrite or report owner
+// rights on an item and has no endpoint for direct report shares, so those
+// principals are reported as skipped for manual follow-up.
+func (s *Service) restoreItemPermissions(ctx context.Context, workspaceID string, backup *models.CompleteBackup, mapping *restoreMapping) []models.ItemResult {
+	results := make([]models.ItemResult, 0)
+
+	logger.LogInfo("🔐 Restoring dataset and report permissions...")
+
+	for _, dataset := range backup.Datasets {
+		targetDatasetID, restored := mapping.datasets[dataset.ID]
+
+		for _, user := range dataset.Users {
+			result := models.ItemResult{
+				ItemType: models.ItemTypeDatasetPermissions,
+				ItemID:   user.Identifier,
+				Name:     fmt.Sprintf("%s: %s", dataset.Name, itemUserName(user)),
+				Outcome:  models.ItemOutcomeSkipped,
+			}
+
+			switch {
+			case !restored:
+				result.Error = "dataset was not restored"
+			case user.AccessRight == "None":
+				result.Error = "no access right to grant"
+			case strings.Contains(user.AccessRight, "Write"):
+				result.Error = fmt.Sprintf("grant %s manually, the API cannot grant this right on a dataset", user.AccessRight)
+				logger.LogWarn(fmt.Sprintf("Dataset permission needs to be granted manually: %s (%s)", result.Name, user.AccessRight))
+			default:
+				result.Attempts = 1
+				err := s.apiClient.AddDatasetUser(ctx, workspaceID, targetDatasetID, map[string]interface{}{
+					"identifier":             user.Identifier,
+					"principalType":          user.PrincipalType,
+					"datasetUserAccessRight": user.AccessRight,
+				})
+				if err != nil {
+					logger.LogError(fmt.Sprintf("❌ Failed to grant %s on %s", user.AccessRight, result.Name), err)
+					result.Outcome = models.ItemOutcomeFailed
+					result.Error = err.Error()
+				} else {
+					result.Outcome = models.ItemOutcomeRestored
+				}
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	for _, report := range backup.Reports {
+		_, restored := mapping.reports[report.ID]
+
+		for _, user := range report.Users {
+			result := models.ItemResult{
+				ItemType: models.ItemTypeReportPermissions,
+				ItemID:   user.Identifier,
+				Name:     fmt.Sprintf("%s: %s", report.Name, itemUserName(user)),
+				Outcome:  models.ItemOutcomeSkipped,
+			}
+
+			switch {
+			case !restored:
+				result.Error = "report was not restored"
+			case user.AccessRight == "Owner" || strings.Contains(user.AccessRight, "Write"):
+				result.Error = fmt.Sprintf("grant %s manually, the API cannot grant this right on a report", user.AccessRight)
+				logger.LogWarn(fmt.Sprintf("Report permission needs to be granted manually: %s (%s)", result.Name, user.AccessRight))
+			default:
+				result.Error = fmt.Sprintf("share %s manually, the API cannot grant direct report access", user.AccessRight)
+				logger.LogWarn(fmt.Sprintf("Report share needs to be recreated manually: %s (%s)", result.Name, user.AccessRight))
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// restoreSubscriptions matches each backed up subscription to the restored report
+// or dashboard. The Power BI API cannot create subscriptions, so every subscription
+// is listed with the details needed to recreate it manually.
+func (s *Service) restoreSubscriptions(backup *models.CompleteBackup, mapping *restoreMapping) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(backup.Subscriptions))
+	if len(backup.Subscriptions) == 0 {
+		return results
+	}
+
+	logger.LogInfo(fmt.Sprintf("📧 Checking %d subscriptions...", len(backup.Subscriptions)))
+
+	for _, subscription := range backup.Subscriptions {
+		result := models.ItemResult{
+			ItemType: models.ItemTypeSubscription,
+			ItemID:   subscription.ID,
+			Name:     fmt.Sprintf("%s: %s", subscription.ArtifactDisplayName, subscription.Title),
+			Outcome:  models.ItemOutcomeSkipped,
+		}
+
+		targets := mapping.reports
+		if subscription.ArtifactType == "Dashboard" {
+			targets = mapping.dashboards
+		}
+
+		targetID, restored := targets[subscription.ArtifactID]
+		if !restored {
+			result.Error = fmt.Sprintf("%s was not restored", strings.ToLower(subscription.ArtifactType))
+			results = append(results, result)
+			continue
+		}
+
+		recipients := make([]string, 0, len(subscription.Users))
+		for _, user := range subscription.Users {
+			recipients = append(recipients, user.EmailAddress)
+		}
+
+		result.Error = fmt.Sprintf("recreate manually on %s %s: %s, %s, recipients %s", strings.ToLower(subscription.ArtifactType),
+			targetID, subscription.Frequency, subscription.AttachmentFormat, strings.Join(recipients, ", "))
+		logger.LogWarn(fmt.Sprintf("Subscription needs to be recreated manually: %s (%s)", result.Name, result.Error))
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// restoreApps lists, for each app published from the source workspace, the
+// restored reports and dashboards it contained. Apps cannot be published through
+// the API, so each app is listed for manual republishing.
+func (s *Service) restoreApps(backup *models.CompleteBackup, mapping *restoreMapping) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(backup.Apps))
+
+	dashboardIDs := make(map[string]string, len(backup.Dashboards))
+	for _, dashboard := range backup.Dashboards {
+		if newID, ok := mapping.dashboards[dashboard.ID]; ok {
+			dashboardIDs[dashboard.DisplayName] = newID
+		}
+	}
+
+	for _, app := range backup.Apps {
+		var contents, missing []string
+		for _, report := range app.Reports {
+			if newID, ok := mapping.reports[report.OriginalReportID]; ok {
+				contents = append(contents, fmt.Sprintf("report %s (%s)", report.Name, newID))
+			} else {
+				missing = append(missing, report.Name)
+			}
+		}
+		// App dashboards do not reference their source, so match them by name
+		for _, dashboard := range app.Dashboards {
+			if newID, ok := dashboardIDs[dashboard.DisplayName]; ok {
+				contents = append(contents, fmt.Sprintf("dashboard %s (%s)", dashboard.DisplayName, newID))
+			} else {
+				missing = append(missing, dashboard.DisplayName)
+			}
+		}
+
+		detail := fmt.Sprintf("republish manually with %s", strings.Join(contents, ", "))
+		if len(missing) > 0 {
+			detail += fmt.Sprintf("; not restored: %s", strings.Join(missing, ", "))
+		}
+
+		logger.LogWarn(fmt.Sprintf("App needs to be republished: %s (%s)", app.Name, detail))
+		results = append(results, models.ItemResult{
+			ItemType: models.ItemTypeAppContents,
+			ItemID:   app.ID,
+			Name:     app.Name,
+			Outcome:  models.ItemOutcomeSkipped,
+			Error:    detail,
+		})
+	}
+
+	return results
+}
+
+func itemUserName(user models.ItemUser) string {
+	if user.EmailAddress != "" {
+		return user.EmailAddress
+	}
+	if user.DisplayName != "" {
+		return user.DisplayName
+	}
+	return user.Identifier
+}
+
+// restoreDashboards recreates dashboards and clones their tiles from the source
+// dashboards, rebinding each tile to the restored report or dataset
+func (s *Service) restoreDashboards(ctx context.Context, workspaceID string, backup *models.CompleteBackup, mapping *restoreMapping) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(backup.Dashboards))
+	if len(backup.Dashboards) == 0 {
+		return results
+	}
+
+	logger.LogInfo(fmt.Sprintf("📈 Restoring %d dashboards...", len(backup.Dashboards)))
+
+	for _, dashboard := range backup.Dashboards {
+		result := models.ItemResult{
+			ItemType: models.ItemTypeDashboard,
+			ItemID:   dashboard.ID,
+			Name:     dashboard.DisplayName,
+			Attempts: 1,
+		}
+
+		created, err := s.apiClient.CreateDashboard(ctx, workspaceID, dashboard.DisplayName)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("❌ Failed to create dashboard: %s", dashboard.DisplayName), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		newDashboardID, _ := created["id"].(string)
+		if newDashboardID == "" {
+			logger.LogError(fmt.Sprintf("❌ Create dashboard returned no ID: %s", dashboard.DisplayName), nil)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = fmt.Sprintf("create dashboard response has no id; %d tiles were not restored", len(dashboard.Tiles))
+			results = append(results, result)
+			continue
+		}
+		mapping.dashboards[dashboard.ID] = newDashboardID
+		logger.LogInfo(fmt.Sprintf("✅ Dashboard created: %s", dashboard.DisplayName))
+		result.Outcome = models.ItemOutcomeRestored
+		results = append(results, result)
+
+		if len(dashboard.Tiles) == 0 {
+			continue
+		}
+
+		// Tiles can only be cloned from a live tile; once the source dashboard is gone
+		// (e.g. its workspace was deleted) they are listed for pinning by hand
+		sourceAvailable := true
+		if _, err := s.apiClient.GetDashboardTiles(ctx, backup.WorkspaceID, dashboard.ID); err != nil {
+			logger.LogWarn(fmt.Sprintf("⚠️  Source dashboard %s is not available, its tiles have to be pinned manually: %v", dashboard.DisplayName, err))
+			sourceAvailable = false
+		}
+
+		for _, tile := range dashboard.Tiles {
+			results = append(results, s.restoreTile(ctx, backup.WorkspaceID, dashboard, workspaceID, newDashboardID, tile, sourceAvailable, mapping))
+		}
+	}
+
+	return results
+}
+
+// restoreTile clones a tile of the source dashboard onto a restored dashboard,
+// pointing it at the restored report and dataset. Without a live source tile the
+// tile is listed with what to pin manually.
+func (s *Service) restoreTile(ctx context.Context, sourceWorkspaceID string, sourceDashboard models.Dashboard, workspaceID, dashboardID string, tile models.Tile, sourceAvailable bool, mapping *restoreMapping) models.ItemResult {
+	result := models.ItemResult{
+		ItemType: models.ItemTypeDashboardTile,
+		ItemID:   tile.ID,
+		Name:     tile.Title,
+		Outcome:  models.ItemOutcomeFailed,
+	}
+
+	var mappedReportID string
+	request := map[string]interface{}{
+		"targetDashboardId":      dashboardID,
+		"targetWorkspaceId":      workspaceID,
+		"positionConflictAction": "Tail",
+	}
+
+	if tile.ReportID != "" {
+		targetReportID, ok := mapping.reports[tile.ReportID]
+		if !ok {
+			result.Error = fmt.Sprintf("report %s was not restored", tile.ReportID)
+			logger.LogWarn(fmt.Sprintf("Skipping tile %s: %s", tile.Title, result.Error))
+			return result
+		}
+		request["targetReportId"] = targetReportID
+		mappedReportID = targetReportID
+	}
+	if tile.DatasetID != "" {
+		targetDatasetID, ok := mapping.datasets[tile.DatasetID]
+		if !ok {
+			result.Error = fmt.Sprintf("dataset %s was not restored", tile.DatasetID)
+			logger.LogWarn(fmt.Sprintf("Skipping tile %s: %s", tile.Title, result.Error))
+			return result
+		}
+		request["targetModelId"] = targetDatasetID
+	}
+
+	if !sourceAvailable {
+		return manualTile(result, sourceDashboard.DisplayName, tile, mappedReportID)
+	}
+
+	result.Attempts = 1
+	if _, err := s.apiClient.CloneTile(ctx, sourceWorkspaceID, sourceDashboard.ID, tile.ID, request); err != nil {
+		if api.IsNotFound(err) {
+			return manualTile(result, sourceDashboard.DisplayName, tile, mappedReportID)
+		}
+		logger.LogError(fmt.Sprintf("❌ Failed to clone tile: %s", tile.Title), err)
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Outcome = models.ItemOutcomeRestored
+	return result
+}
+
+// manualTile records a tile that cannot be cloned because its source no longer exists,
+// with the details needed to pin it again from the restored report
+func manualTile(result models.ItemResult, dashboardName string, tile models.Tile, reportID string) models.ItemResult {
+	source := "the restored content"
+	if reportID != "" {
+		source = fmt.Sprintf("restored report %s", reportID)
+	}
+	title := tile.Title
+	if title == "" {
+		title = tile.ID
+	}
+
+	details := fmt.Sprintf("source dashboard no longer exists; pin %q from %s to dashboard %q (%dx%d)",
+		title, source, dashboardName, tile.ColSpan, tile.RowSpan)
+	if tile.SubTitle != "" {
+		details += fmt.Sprintf(", subtitle %q", tile.SubTitle)
+	}
+	if tile.EmbedURL != "" {
+		details += fmt.Sprintf(", originally %s", tile.EmbedURL)
+	}
+
+	logger.LogWarn(fmt.Sprintf("⚠️  Tile %s needs to be pinned manually", title))
+	result.Outcome = models.ItemOutcomeSkipped
+	result.Error = details
+	return result
+}
+
+// restoreModelDefinitions creates a semantic model from its saved definition for
+// every backed up dataset that was not restored through a PBIX import
+func (s *Service) restoreModelDefinitions(ctx context.Context, workspaceID, backupPath string, datasets []models.Dataset, mapping *restoreMapping) []models.ItemResult {
+	results := []models.ItemResult{}
+
+	for _, dataset := range datasets {
+		if _, ok := mapping.datasets[dataset.ID]; ok || dataset.DefinitionDir == "" {
+			continue
+		}
+
+		logger.LogInfo(fmt.Sprintf("🧩 Creating semantic model from definition: %s", dataset.Name))
+
+		result := models.ItemResult{
+			ItemType: models.ItemTypeModelDefinition,
+			ItemID:   dataset.ID,
+			Name:     dataset.Name,
+			Attempts: 1,
+		}
+
+		newID, err := s.createModelFromDefinition(ctx, workspaceID, backupPath, dataset)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("❌ Failed to create semantic model: %s", dataset.Name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		mapping.datasets[dataset.ID] = newID
+		logger.LogInfo(fmt.Sprintf("✅ Semantic model created: %s", dataset.Name))
+		result.Outcome = models.ItemOutcomeRestored
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// createModelFromDefinition creates a semantic model from the definition parts
+// saved for dataset and returns the new dataset ID
+func (s *Service) createModelFromDefinition(ctx context.Context, workspaceID, backupPath string, dataset models.Dataset) (string, error) {
+	parts, err := storage.LoadDefinitionParts(filepath.Join(backupPath, filepath.FromSlash(dataset.DefinitionDir)))
+	if err != nil {
+		return "", err
+	}
+
+	item, err := s.apiClient.CreateItem(ctx, workspaceID, dataset.Name, "SemanticModel", parts)
+	if err != nil {
+		return "", err
+	}
+
+	newID, _ := item["id"].(string)
+	if newID == "" {
+		return "", fmt.Errorf("created semantic model has no ID")
+	}
+	return newID, nil
+}
+
+// restoreDataflows recreates dataflows by importing their model.json definitions
+func (s *Service) restoreDataflows(ctx context.Context, workspaceID, backupPath string, dataflows []models.Dataflow) []models.ItemResult {
+	results := make([]models.ItemResult, 0, len(dataflows))
+	if len(dataflows) == 0 {
+		return results
+	}
+
+	logger.LogInfo(fmt.Sprintf("🌊 Restoring %d dataflows...", len(dataflows)))
+
+	for _, dataflow := range dataflows {
+		result := models.ItemResult{
+			ItemType: models.ItemTypeDataflow,
+			ItemID:   dataflow.ObjectID,
+			Name:     dataflow.Name,
+		}
+
+		if dataflow.DefinitionFile == "" {
+			logger.LogWarn(fmt.Sprintf("No definition in backup for dataflow: %s", dataflow.Name))
+			result.Outcome = models.ItemOutcomeSkipped
+			result.Error = "no definition in backup"
+			results = append(results, result)
+			continue
+		}
+
+		result.Attempts = 1
+		definitionFile := filepath.Join(backupPath, filepath.FromSlash(dataflow.DefinitionFile))
+		importID, err := s.importDataflow(ctx, workspaceID, definitionFile)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("❌ Failed to import dataflow: %s", dataflow.Name), err)
+			result.Outcome = models.ItemOutcomeFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		// Datasets imported later may load from the dataflow, so wait for it to exist
+		if importID != "" {
+			if _, err := s.waitForImport(ctx, workspaceID, importID); err != nil {
+				logger.LogError(fmt.Sprintf("❌ Dataflow import did not complete: %s", dataflow.Name), err)
+				result.Outcome = models.ItemOutcomeFailed
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+		}
+
+		logger.LogInfo(fmt.Sprintf("✅ Dataflow imported: %s", dataflow.Name))
+		result.Outcome = models.ItemOutcomeRestored
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// importDataflow imports a dataflow definition after removing the entity
+// partitions, which point at the storage of the source dataflow, and returns the import ID
+func (s *Service) importDataflow(ctx context.Context, workspaceID, definitionFile string) (string, error) {
+	data, err := os.ReadFile(definitionFile)
+	if err != nil {
+		return "", err
+	}
+
+	var definition map[string]interface{}
+	if err := json.Unmarshal(data, &definition); err != nil {
+		return "", fmt.Errorf("invalid dataflow definition: %w", err)
+	}
+
+	if entities, ok := definition["entities"].([]interface{}); ok {
+		for _, entity := range entities {
+			if entityMap, ok := entity.(map[string]interface{}); ok {
+				delete(entityMap, "partitions")
+			}
+		}
+	}
+
+	tempDir, err := os.MkdirTemp("", "dataflow-import-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	modelFile := filepath.Join(tempDir, "model.json")
+	data, err = json.Marshal(definition)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(modelFile, data, 0644); err != nil {
+		return "", err
+	}
+
+	importResult, err := s.apiClient.ImportDataflow(ctx, workspaceID, modelFile)
+	if err != nil {
+		return "", err
+	}
+	importID, _ := importResult["id"].(string)
+	return importID, nil
+}
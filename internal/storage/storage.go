@@ -3,14 +3,38 @@ package storage
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/veeam/powerbi-backup-go/internal/logger"
 	"github.com/veeam/powerbi-backup-go/internal/models"
 )
 
+const (
+	// backupFileName is written last, so its presence marks a finished backup
+	backupFileName = "complete_backup.json"
+	// stagingPrefix marks backup directories that are still being written
+	stagingPrefix = ".staging-"
+	// timestampFormat names backup directories
+	timestampFormat = "2006-01-02_15-04-05"
+	// maxFileNameLength caps the name part of artifact file names
+	maxFileNameLength = 100
+	// runsDirName holds the checkpoints of tenant-wide backup runs
+	runsDirName = ".runs"
+	// staleStagingAge is how long a staging directory must go without writes before
+	// it is treated as abandoned; a backup in another process keeps writing to it
+	staleStagingAge = 6 * time.Hour
+	// restoresDirName holds the restore reports of a backup, inside its directory
+	restoresDirName = "restores"
+	// tenantDirName holds tenant-level backups such as the gateway inventory
+	tenantDirName = "tenant"
+	// pipelinesKind is the tenant directory of deployment pipeline inventories
+	pipelinesKind = "pipelines"
+)
+
 // StorageService handles backup storage operations
 type StorageService struct {
 	backupPath string
@@ -28,22 +52,66 @@ func (s *StorageService) GetBackupPath() string {
 	return s.backupPath
 }
 
-// SaveBackup saves a complete backup to the file system
+// ResolvePath makes a caller-supplied path absolute and checks that it lies inside
+// the backup directory, or inside the given subdirectory of it
+func (s *StorageService) ResolvePath(path string, subdirs ...string) (string, error) {
+	root, err := filepath.Abs(filepath.Join(append([]string{s.backupPath}, subdirs...)...))
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside %s", path, root)
+	}
+	return resolved, nil
+}
+
+// PipelineInventoryPath resolves a caller-supplied pipeline inventory file, which
+// must lie inside the tenant pipelines directory
+func (s *StorageService) PipelineInventoryPath(path string) (string, error) {
+	return s.ResolvePath(path, tenantDirName, pipelinesKind)
+}
+
+// StagingDir returns the directory a backup is written to while it is in progress.
+// Staging directories are hidden from listings until SaveBackup promotes them.
+func (s *StorageService) StagingDir(workspaceID string, timestamp time.Time) string {
+	return filepath.Join(s.backupPath, workspaceID, stagingPrefix+timestamp.Format(timestampFormat))
+}
+
+// BackupDir returns the final directory of a promoted backup
+func (s *StorageService) BackupDir(workspaceID string, timestamp time.Time) string {
+	return filepath.Join(s.backupPath, workspaceID, timestamp.Format(timestampFormat))
+}
+
+// DiscardStaging removes the staging directory of a backup that was abandoned
+func (s *StorageService) DiscardStaging(workspaceID string, timestamp time.Time) {
+	stagingDir := s.StagingDir(workspaceID, timestamp)
+	if err := os.RemoveAll(stagingDir); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to remove staging directory: %s", stagingDir), err)
+	}
+}
+
+// SaveBackup writes the backup metadata into its staging directory and
+// atomically promotes the staging directory to its final timestamp directory
 func (s *StorageService) SaveBackup(backup *models.CompleteBackup) (string, error) {
-	// Create workspace directory
-	timestamp := backup.Timestamp.Format("2006-01-02_15-04-05")
-	backupDir := filepath.Join(s.backupPath, backup.WorkspaceID, timestamp)
+	stagingDir := s.StagingDir(backup.WorkspaceID, backup.Timestamp)
+	backupDir := s.BackupDir(backup.WorkspaceID, backup.Timestamp)
 
-	if err := os.MkdirAll(backupDir, 0755); err != nil {
-		logger.LogError(fmt.Sprintf("Failed to create backup directory: %s", backupDir), err)
+	if err := os.MkdirAll(stagingDir, 0755); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to create staging directory: %s", stagingDir), err)
 		return "", err
 	}
 
-	// Note: PBIX files are already created in backupDir by BackupWorkspace()
+	// Note: PBIX files are already created in stagingDir by BackupWorkspace()
 	// This function just saves the JSON metadata files alongside them
 
 	// Save complete backup as JSON
-	backupFile := filepath.Join(backupDir, "complete_backup.json")
+	backupFile := filepath.Join(stagingDir, backupFileName)
 	data, err := json.MarshalIndent(backup, "", "  ")
 	if err != nil {
 		logger.LogError("Failed to marshal backup data", err)
@@ -56,13 +124,22 @@ func (s *StorageService) SaveBackup(backup *models.CompleteBackup) (string, erro
 	}
 
 	// Save individual components
-	s.saveComponent(backupDir, "reports.json", backup.Reports)
-	s.saveComponent(backupDir, "datasets.json", backup.Datasets)
-	s.saveComponent(backupDir, "dataflows.json", backup.Dataflows)
-	s.saveComponent(backupDir, "dashboards.json", backup.Dashboards)
-	s.saveComponent(backupDir, "apps.json", backup.Apps)
-	s.saveComponent(backupDir, "refresh_schedules.json", backup.RefreshSchedules)
-	s.saveComponent(backupDir, "workspace_settings.json", backup.WorkspaceSettings)
+	s.saveComponent(stagingDir, "reports.json", backup.Reports)
+	s.saveComponent(stagingDir, "datasets.json", backup.Datasets)
+	s.saveComponent(stagingDir, "dataflows.json", backup.Dataflows)
+	s.saveComponent(stagingDir, "fabric_items.json", backup.FabricItems)
+	s.saveComponent(stagingDir, "dashboards.json", backup.Dashboards)
+	s.saveComponent(stagingDir, "apps.json", backup.Apps)
+	s.saveComponent(stagingDir, "refresh_schedules.json", backup.RefreshSchedules)
+	s.saveComponent(stagingDir, "subscriptions.json", backup.Subscriptions)
+	s.saveComponent(stagingDir, "users.json", backup.Users)
+	s.saveComponent(stagingDir, "workspace_settings.json", backup.WorkspaceSettings)
+
+	// Promote the staging directory - rename within the same parent is atomic
+	if err := os.Rename(stagingDir, backupDir); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to promote backup directory: %s", stagingDir), err)
+		return "", err
+	}
 
 	logger.LogInfo(fmt.Sprintf("Backup saved successfully: %s", backupDir))
 	return backupDir, nil
@@ -87,7 +164,7 @@ func (s *StorageService) saveComponent(dir, filename string, data interface{}) e
 
 // LoadBackup loads a backup from the file system
 func (s *StorageService) LoadBackup(backupPath string) (*models.CompleteBackup, error) {
-	backupFile := filepath.Join(backupPath, "complete_backup.json")
+	backupFile := filepath.Join(backupPath, backupFileName)
 
 	data, err := os.ReadFile(backupFile)
 	if err != nil {
@@ -121,14 +198,424 @@ func (s *StorageService) ListBackups(workspaceID string) ([]string, error) {
 
 	var backups []string
 	for _, entry := range entries {
-		if entry.IsDir() {
-			backups = append(backups, filepath.Join(workspaceDir, entry.Name()))
+		if !entry.IsDir() || IsStagingDir(entry.Name()) {
+			continue
+		}
+
+		dir := filepath.Join(workspaceDir, entry.Name())
+		if !IsCompleteBackupDir(dir) {
+			logger.LogDebug(fmt.Sprintf("Ignoring incomplete backup directory: %s", dir))
+			continue
 		}
+		backups = append(backups, dir)
 	}
 
 	return backups, nil
 }
 
+// ListWorkspaces lists the IDs of the workspaces with a backup directory
+func (s *StorageService) ListWorkspaces() ([]string, error) {
+	entries, err := os.ReadDir(s.backupPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	workspaces := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == runsDirName || entry.Name() == tenantDirName {
+			continue
+		}
+		workspaces = append(workspaces, entry.Name())
+	}
+	return workspaces, nil
+}
+
+// FindIncompleteBackups returns backup directories left behind by interrupted backups:
+// staging directories and timestamp directories without a complete_backup.json.
+// Staging directories an unfinished run can resume, and staging directories written
+// to within staleStagingAge (possibly by a backup running in another process), are
+// not reported.
+func (s *StorageService) FindIncompleteBackups() ([]string, error) {
+	workspaceDirs, err := os.ReadDir(s.backupPath)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to read backup directory: %s", s.backupPath), err)
+		return nil, err
+	}
+
+	// Staging directories of unfinished runs are resumed, not abandoned
+	resumable := s.resumableStagingDirs()
+
+	incomplete := []string{}
+	for _, workspaceDir := range workspaceDirs {
+		if !workspaceDir.IsDir() || workspaceDir.Name() == runsDirName || workspaceDir.Name() == tenantDirName {
+			continue
+		}
+
+		wsPath := filepath.Join(s.backupPath, workspaceDir.Name())
+		entries, err := os.ReadDir(wsPath)
+		if err != nil {
+			logger.LogError(fmt.Sprintf("Failed to read workspace directory: %s", wsPath), err)
+			continue
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			dir := filepath.Join(wsPath, entry.Name())
+			if resumable[dir] {
+				logger.LogDebug(fmt.Sprintf("Keeping staging directory of an unfinished run: %s", dir))
+				continue
+			}
+			if IsStagingDir(entry.Name()) {
+				if lastWrite := lastModified(dir); time.Since(lastWrite) < staleStagingAge {
+					logger.LogDebug(fmt.Sprintf("Keeping staging directory written to at %s: %s", lastWrite.Format(time.RFC3339), dir))
+					continue
+				}
+				incomplete = append(incomplete, dir)
+			} else if !IsCompleteBackupDir(dir) {
+				incomplete = append(incomplete, dir)
+			}
+		}
+	}
+
+	return incomplete, nil
+}
+
+// CheckIncompleteBackups reports incomplete backups left by earlier runs and,
+// when cleanup is true, removes them. Returns the directories that were found.
+func (s *StorageService) CheckIncompleteBackups(cleanup bool) ([]string, error) {
+	incomplete, err := s.FindIncompleteBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range incomplete {
+		if !cleanup {
+			logger.LogWarn(fmt.Sprintf("⚠️  Incomplete backup found (set CLEANUP_INCOMPLETE_BACKUPS=true to remove): %s", dir))
+			continue
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			logger.LogError(fmt.Sprintf("Failed to remove incomplete backup: %s", dir), err)
+			continue
+		}
+		logger.LogInfo(fmt.Sprintf("🧹 Removed incomplete backup: %s", dir))
+	}
+
+	return incomplete, nil
+}
+
+// lastModified returns the most recent modification time of a directory or anything in it
+func lastModified(dir string) time.Time {
+	var latest time.Time
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest
+}
+
+// SaveRestoreReport writes the per-item result of a restore next to the backup it
+// restored, as restores/{timestamp}.json in the backup directory
+func (s *StorageService) SaveRestoreReport(result *models.RestoreResult) (string, error) {
+	backupDir, err := s.ResolvePath(result.BackupPath)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(backupDir, restoresDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to create restore report directory: %s", dir), err)
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		logger.LogError("Failed to marshal restore report", err)
+		return "", err
+	}
+
+	reportFile := filepath.Join(dir, result.Timestamp.Format(timestampFormat)+".json")
+	tmpFile := reportFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to write restore report: %s", tmpFile), err)
+		return "", err
+	}
+	if err := os.Rename(tmpFile, reportFile); err != nil {
+		return "", err
+	}
+
+	logger.LogInfo(fmt.Sprintf("Restore report saved: %s", reportFile))
+	return reportFile, nil
+}
+
+// ListRestoreReports loads the restore reports saved for a backup, oldest first
+func (s *StorageService) ListRestoreReports(backupPath string) ([]*models.RestoreResult, error) {
+	backupDir, err := s.ResolvePath(backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(backupDir, restoresDirName, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*models.RestoreResult, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			logger.LogWarn(fmt.Sprintf("Failed to read restore report %s: %v", file, err))
+			continue
+		}
+		var report models.RestoreResult
+		if err := json.Unmarshal(data, &report); err != nil {
+			logger.LogWarn(fmt.Sprintf("Failed to parse restore report %s: %v", file, err))
+			continue
+		}
+		reports = append(reports, &report)
+	}
+	return reports, nil
+}
+
+// SaveGatewayInventory writes a gateway inventory to tenant/gateways/{timestamp}.json
+func (s *StorageService) SaveGatewayInventory(inventory *models.GatewayInventory) (string, error) {
+	return s.saveTenantFile("gateways", inventory.Timestamp, inventory)
+}
+
+// SavePipelineInventory writes a pipeline inventory to tenant/pipelines/{timestamp}.json
+func (s *StorageService) SavePipelineInventory(inventory *models.PipelineInventory) (string, error) {
+	return s.saveTenantFile(pipelinesKind, inventory.Timestamp, inventory)
+}
+
+// LoadPipelineInventory reads a pipeline inventory written by SavePipelineInventory
+func (s *StorageService) LoadPipelineInventory(inventoryFile string) (*models.PipelineInventory, error) {
+	data, err := os.ReadFile(inventoryFile)
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to read pipeline inventory: %s", inventoryFile), err)
+		return nil, err
+	}
+
+	var inventory models.PipelineInventory
+	if err := json.Unmarshal(data, &inventory); err != nil {
+		logger.LogError("Failed to unmarshal pipeline inventory", err)
+		return nil, err
+	}
+
+	return &inventory, nil
+}
+
+// saveTenantFile writes a tenant-level backup to tenant/{kind}/{timestamp}.json
+func (s *StorageService) saveTenantFile(kind string, timestamp time.Time, data interface{}) (string, error) {
+	dir := filepath.Join(s.backupPath, tenantDirName, kind)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to create %s directory: %s", kind, dir), err)
+		return "", err
+	}
+
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		logger.LogError(fmt.Sprintf("Failed to marshal %s inventory", kind), err)
+		return "", err
+	}
+
+	// Write under a temporary name so a partial file is never mistaken for an inventory
+	inventoryFile := filepath.Join(dir, timestamp.Format(timestampFormat)+".json")
+	tmpFile := inventoryFile + ".tmp"
+	if err := os.WriteFile(tmpFile, content, 0644); err != nil {
+		logger.LogError(fmt.Sprintf("Failed to write %s inventory: %s", kind, tmpFile), err)
+		return "", err
+	}
+	if err := os.Rename(tmpFile, inventoryFile); err != nil {
+		return "", err
+	}
+
+	logger.LogInfo(fmt.Sprintf("Inventory saved successfully: %s", inventoryFile))
+	return inventoryFile, nil
+}
+
+// runFile returns the checkpoint file of a backup run
+func (s *StorageService) runFile(runID string) string {
+	return filepath.Join(s.backupPath, runsDirName, runID+".json")
+}
+
+// SaveRun writes the checkpoint of a backup run, replacing the previous one atomically
+func (s *StorageService) SaveRun(run *models.BackupRun) error {
+	if SanitizeFileName(run.RunID) != run.RunID {
+		return fmt.Errorf("invalid run ID: %s", run.RunID)
+	}
+
+	runFile := s.runFile(run.RunID)
+	if err := os.MkdirAll(filepath.Dir(runFile), 0755); err != nil {
+		return err
+	}
+
+	run.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFile := runFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, runFile)
+}
+
+// LoadRun reads the checkpoint of a backup run. Returns nil without an error
+// when no run with this ID exists.
+func (s *StorageService) LoadRun(runID string) (*models.BackupRun, error) {
+	if SanitizeFileName(runID) != runID {
+		return nil, fmt.Errorf("invalid run ID: %s", runID)
+	}
+
+	data, err := os.ReadFile(s.runFile(runID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var run models.BackupRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint for run %s: %w", runID, err)
+	}
+	return &run, nil
+}
+
+// resumableStagingDirs returns the staging directories of workspaces that an
+// unfinished run was backing up when it stopped
+func (s *StorageService) resumableStagingDirs() map[string]bool {
+	dirs := make(map[string]bool)
+
+	entries, err := os.ReadDir(filepath.Join(s.backupPath, runsDirName))
+	if err != nil {
+		return dirs
+	}
+
+	for _, entry := range entries {
+		runID, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+		run, err := s.LoadRun(runID)
+		if err != nil || run == nil || run.Status == models.RunStatusCompleted {
+			continue
+		}
+		for _, ws := range run.Workspaces {
+			if ws.Status == models.RunStatusInProgress && ws.StagingTime != nil {
+				dirs[s.StagingDir(ws.WorkspaceID, *ws.StagingTime)] = true
+			}
+		}
+	}
+
+	return dirs
+}
+
+// ArtifactFileName builds a file name for an exported item that is safe on every
+// platform and unique within the backup: the sanitized item name plus its ID
+func ArtifactFileName(name, id, ext string) string {
+	return fmt.Sprintf("%s_%s%s", SanitizeFileName(name), id, ext)
+}
+
+// SanitizeFileName replaces characters that are invalid in file names
+func SanitizeFileName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r < 32 || strings.ContainsRune(`<>:"/\|?*`, r) {
+			b.WriteRune('_')
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	// Windows rejects trailing dots and spaces
+	sanitized := strings.TrimRight(strings.TrimSpace(b.String()), ". ")
+
+	runes := []rune(sanitized)
+	if len(runes) > maxFileNameLength {
+		sanitized = strings.TrimRight(string(runes[:maxFileNameLength]), ". ")
+	}
+
+	if sanitized == "" {
+		return "item"
+	}
+	return sanitized
+}
+
+// SaveDefinitionParts writes the parts of an item definition below dir,
+// keeping each part's relative path
+func SaveDefinitionParts(dir string, parts []models.DefinitionPart) error {
+	for _, part := range parts {
+		partPath := filepath.Join(dir, filepath.FromSlash(part.Path))
+		rel, err := filepath.Rel(dir, partPath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("invalid definition part path: %s", part.Path)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(partPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(partPath, part.Payload, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadDefinitionParts reads back the definition parts saved below dir
+func LoadDefinitionParts(dir string) ([]models.DefinitionPart, error) {
+	parts := []models.DefinitionPart{}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		payload, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		parts = append(parts, models.DefinitionPart{Path: filepath.ToSlash(rel), Payload: payload})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parts, nil
+}
+
+// IsStagingDir reports whether a directory name belongs to an in-progress backup
+func IsStagingDir(name string) bool {
+	return strings.HasPrefix(name, stagingPrefix)
+}
+
+// IsCompleteBackupDir reports whether a directory holds a finished backup
+func IsCompleteBackupDir(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, backupFileName))
+	return err == nil
+}
+
 // GetLatestBackup gets the most recent backup for a workspace
 func (s *StorageService) GetLatestBackup(workspaceID string) (string, error) {
 	backups, err := s.ListBackups(workspaceID)
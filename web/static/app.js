@@ -120,6 +120,7 @@ async function handleBackup(event) {
     
     const workspaceId = document.getElementById('workspaceId').value;
     const backupAll = document.getElementById('backupAll').checked;
+    const metadataOnly = document.getElementById('metadataOnly').checked;
     
     if (!backupAll && !workspaceId) {
         showNotification('⚠️ Please enter a workspace ID or select "Backup All"', 'warning');
@@ -138,7 +139,8 @@ async function handleBackup(event) {
             },
             body: JSON.stringify({
                 workspace_id: workspaceId,
-                all: backupAll
+                all: backupAll,
+                metadata_only: metadataOnly
             })
         });
         
@@ -194,7 +196,8 @@ async function loadBackups() {
                     const option = document.createElement('option');
                     option.value = backup.path;
                     const date = new Date(backup.timestamp).toLocaleString();
-                    option.textContent = `${backup.workspace_name} - ${date}`;
+                    const status = backup.status ? ` [${backup.status}]` : '';
+                    option.textContent = `${backup.workspace_name} - ${date}${status}`;
                     select.appendChild(option);
                 });
                 showNotification('✅ Backups loaded successfully', 'success');
@@ -216,6 +219,9 @@ async function handleRestore(event) {
     
     const workspaceId = document.getElementById('restoreWorkspaceId').value;
     const backupPath = document.getElementById('restoreBackupSelect').value;
+    const restoreAccess = document.getElementById('restoreAccess').checked;
+    const restoreItemPermissions = document.getElementById('restoreItemPermissions').checked;
+    const assignCapacity = document.getElementById('assignCapacity').checked;
     
     if (!workspaceId || !backupPath) {
         showNotification('⚠️ Please select a backup and enter a workspace ID', 'warning');
@@ -234,7 +240,10 @@ async function handleRestore(event) {
             },
             body: JSON.stringify({
                 workspace_id: workspaceId,
-                backup_path: backupPath
+                backup_path: backupPath,
+                restore_access: restoreAccess,
+                restore_item_permissions: restoreItemPermissions,
+                assign_capacity: assignCapacity
             })
         });
         
@@ -263,6 +272,16 @@ async function handleRestore(event) {
     }
 }
 
+// Escape a value for insertion into HTML - names and API error bodies are user controlled
+function escapeHTML(value) {
+    return String(value ?? '')
+        .replace(/&/g, '&amp;')
+        .replace(/</g, '&lt;')
+        .replace(/>/g, '&gt;')
+        .replace(/"/g, '&quot;')
+        .replace(/'/g, '&#39;');
+}
+
 // Load History
 async function loadHistory() {
     const container = document.getElementById('historyContainer');
@@ -283,11 +302,17 @@ async function loadHistory() {
             let html = '<div class="history-list">';
             backupsList.forEach(backup => {
                 const date = new Date(backup.timestamp).toLocaleString();
-                const workspaceName = backup.workspace_name || 'Unknown Workspace';
+                const workspaceName = escapeHTML(backup.workspace_name || 'Unknown Workspace');
+                const statusBadge = backup.status
+                    ? `<span class="history-status status-${escapeHTML(backup.status.toLowerCase())}">${escapeHTML(backup.status)}</span>`
+                    : '';
+                const failures = (backup.failed_items || [])
+                    .map(item => `<li>${escapeHTML(item.itemType)} ${escapeHTML(item.name)}: ${escapeHTML(item.error)} (${escapeHTML(item.attempts)} attempt(s))</li>`)
+                    .join('');
                 html += `
                     <div class="history-item">
                         <div class="history-header">
-                            <h4>${workspaceName}</h4>
+                            <h4>${workspaceName}${statusBadge}</h4>
                             <span class="history-date">${date}</span>
                         </div>
                         <div class="history-details">
@@ -296,9 +321,15 @@ async function loadHistory() {
                             <span>📈 Dashboards: ${backup.dashboards || 0}</span>
                             <span>🌊 Dataflows: ${backup.dataflows || 0}</span>
                             <span>📱 Apps: ${backup.apps || 0}</span>
+                            <span>✅ Exported: ${backup.exported || 0}</span>
+                            <span>⏭️ Skipped: ${backup.skipped || 0}</span>
+                            <span>❌ Failed: ${backup.failed || 0}</span>
                         </div>
+                        ${backup.components ? `<div class="history-components"><small>Components: ${escapeHTML(backup.components.join(', '))}</small></div>` : ''}
+                        ${failures ? `<ul class="history-failures">${failures}</ul>` : ''}
+                        ${renderRestores(backup.restores)}
                         <div class="history-path">
-                            <small>Path: ${backup.path}</small>
+                            <small>Path: ${escapeHTML(backup.path)}</small>
                         </div>
                     </div>
                 `;
@@ -315,6 +346,25 @@ async function loadHistory() {
     }
 }
 
+// Render the restore reports saved for a backup
+function renderRestores(restores) {
+    if (!restores || restores.length === 0) {
+        return '';
+    }
+    return restores.map(restore => {
+        const date = new Date(restore.timestamp).toLocaleString();
+        const items = (restore.attention_items || [])
+            .map(item => `<li>${escapeHTML(item.outcome)} ${escapeHTML(item.itemType)} ${escapeHTML(item.name)}: ${escapeHTML(item.error)}</li>`)
+            .join('');
+        return `
+            <div class="history-restore">
+                <small>♻️ Restored to ${escapeHTML(restore.target_workspace_id)} on ${date} — ✅ ${restore.restored || 0} ⏭️ ${restore.skipped || 0} ❌ ${restore.failed || 0}</small>
+                ${items ? `<ul class="history-failures">${items}</ul>` : ''}
+            </div>
+        `;
+    }).join('');
+}
+
 // Show Notification
 function showNotification(message, type = 'info') {
     const notification = document.getElementById('notification');